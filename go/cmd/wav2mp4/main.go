@@ -17,6 +17,8 @@ import (
 	"github.com/transcriptaze/wav2png/go/compositor"
 	"github.com/transcriptaze/wav2png/go/cursors"
 	"github.com/transcriptaze/wav2png/go/encoding"
+	"github.com/transcriptaze/wav2png/go/peaks"
+	"github.com/transcriptaze/wav2png/go/renderers/radial"
 	"github.com/transcriptaze/wav2png/go/styles"
 )
 
@@ -105,10 +107,12 @@ func main() {
 		exit(err)
 	} else if style, err = makeStyle(); err != nil {
 		exit(err)
-	} else if audio, fs, from, to, err = getAudio(wavfile); err != nil {
+	} else if audio, fs, from, to, err = getAudio(wavfile, style); err != nil {
 		exit(err)
 	}
 
+	style = style.WithSampleRate(fs)
+
 	dir := filepath.Join(filepath.Dir(outfile), "frames")
 	if err := os.MkdirAll(dir, 0777); err != nil {
 		exit(err)
@@ -121,6 +125,12 @@ func main() {
 
 	cursor := opts.cursor.Render(h)
 	fn := opts.cursor.Fn()
+
+	_, circular := style.Renderer().(radial.Radial)
+	if circular {
+		_, _, R := radial.Geometry(w, h)
+		cursor = opts.cursor.Render(int(R))
+	}
 	duration := to - from
 	window := opts.window
 	fps := opts.fps
@@ -170,14 +180,16 @@ func main() {
 			exit(fmt.Errorf("frame %d - invalid frame 'end' (%v)", frame, end))
 		}
 
-		img, err := render(audio, fs, start, end, shift, style)
+		img, err := render(audio, fs, start, end, shift, style.WithPlayhead(x))
 		if err != nil {
 			exit(err)
 		} else if img == nil {
 			exit(fmt.Errorf("error creating frame"))
 		}
 
-		if cursor != nil {
+		if cursor != nil && circular {
+			arm(img, cursor, image.Rect(x0, y0, x0+w, y0+h), x)
+		} else if cursor != nil {
 			cw := cursor.Bounds().Dx()
 			ch := cursor.Bounds().Dy()
 			cx := x0 + int(math.Round(x*float64(w-1))) - cw/2
@@ -281,10 +293,14 @@ func makeStyle() (style styles.Style, err error) {
 	return
 }
 
-func getAudio(file string) (pcm []float32, fs float64, from, to time.Duration, err error) {
+func getAudio(file string, style styles.Style) (pcm []float32, fs float64, from, to time.Duration, err error) {
 	var f *os.File
 	var audio encoding.Audio
 
+	if ext := strings.ToLower(filepath.Ext(file)); ext == ".dat" || ext == ".json" {
+		return getPeaks(file, style)
+	}
+
 	if f, err = os.Open(file); err != nil {
 		return
 	}
@@ -322,6 +338,53 @@ func getAudio(file string) (pcm []float32, fs float64, from, to time.Duration, e
 	return
 }
 
+func getPeaks(file string, style styles.Style) (pcm []float32, fs float64, from, to time.Duration, err error) {
+	var f *os.File
+	var p *peaks.Peaks
+
+	if f, err = os.Open(file); err != nil {
+		return
+	}
+
+	defer f.Close()
+
+	if p, err = peaks.Decode(f); err != nil {
+		return
+	}
+
+	if opts.debug {
+		fmt.Println()
+		fmt.Printf("   File:        %v\n", file)
+		fmt.Printf("   Sample Rate: %v\n", p.SampleRate)
+		fmt.Printf("   Resolution:  %v bits\n", p.Bits)
+		fmt.Printf("   Samples/px:  %v\n", p.SamplesPerPixel)
+		fmt.Printf("   Duration:    %v\n", p.Duration())
+		fmt.Printf("   Peaks:       %v\n", p.Length())
+		fmt.Println()
+	}
+
+	from = 0 * time.Second
+	to = p.Duration()
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "start" && opts.start < p.Duration() {
+			from = opts.start
+		} else if f.Name == "end" && opts.end < p.Duration() {
+			to = opts.end
+		}
+	})
+
+	// ... at least one min/max pair per pixel for each frame 'window'
+	pixels := int(style.Width())
+	if opts.window > 0 && opts.window < p.Duration() {
+		pixels = int(math.Ceil(float64(style.Width()) * p.Duration().Seconds() / opts.window.Seconds()))
+	}
+
+	pcm, fs = p.Samples(2 * pixels)
+
+	return
+}
+
 func render(audio []float32, fs float64, from, to time.Duration, shift float64, style styles.Style) (*image.NRGBA, error) {
 	duration := func() time.Duration {
 		return time.Duration(math.Floor(float64(len(audio))/fs)) * time.Second
@@ -337,7 +400,7 @@ func render(audio []float32, fs float64, from, to time.Duration, shift float64,
 		return nil, fmt.Errorf("end position not in range %v-%v", from, duration())
 	}
 
-	compositor := compositor.FromStyle(style)
+	compositor := compositor.FromStyle(style).WithTimeRange(from, to)
 
 	return compositor.Render(audio[start:end])
 }
@@ -385,7 +448,8 @@ func help() {
 	fmt.Println("   Usage: wav2mp4 [--debug] [options] [--out <filepath>] --window <window> --fps <frame rate> --cursor <cursorspec> <filename>")
 	fmt.Println()
 	fmt.Println()
-	fmt.Println("       <wav>                  WAV file to render.")
+	fmt.Println("       <wav>                  WAV file to render. May also be an audiowaveform peaks file (.dat or .json), in")
+	fmt.Println("                              which case the frames are rendered from the precomputed min/max peaks.")
 	fmt.Println()
 	fmt.Println("       --out <path>           File path for MP4 file - if <path> is a directory, the WAV file name is")
 	fmt.Println("                              used and defaults to the WAV file base path. wav2mp4 generates a set of ffmpeg frames ")
@@ -417,6 +481,12 @@ func help() {
 	fmt.Println("                              - erf    Moves 'sigmoidally' from left to right over the duration of the MP4, with the ")
 	fmt.Println("                                       sigmoid defined by the inverse error function")
 	fmt.Println()
+	fmt.Println("                              For styles with a 'radial' renderer the cursor is drawn as an arm rotating clockwise")
+	fmt.Println("                              from 12 o'clock, with the cursor dynamic determining the angle of the arm.")
+	fmt.Println()
+	fmt.Println("                              For lines and columns styles with a 'horizontal' colouring with a 'played' palette,")
+	fmt.Println("                              the waveform to the left of the cursor is rendered with the 'played' palette.")
+	fmt.Println()
 	fmt.Println("       --debug                Displays occasionally useful diagnostic information.")
 	fmt.Println()
 	fmt.Println()
@@ -437,12 +507,19 @@ func help() {
 	fmt.Println("    --palette  <palette>   Palette used to colour the waveform. May be the name of one of the internal colour palettes")
 	fmt.Println("                           or a user provided PNG file. Defaults to 'ice'")
 	fmt.Println()
-	fmt.Println("    --fill <fillspec>      Fill specification for the background colour, in the form type:colour e.g. solid:#0000ffff")
+	fmt.Println("    --fill <fillspec>      Fill specification for the background, in the form type:colour[:...] e.g.")
+	fmt.Println("                           - none (transparent background)")
+	fmt.Println("                           - solid:#0000ffff")
+	fmt.Println("                           - linear:#000000ff:#000080ff[:angle] (angle in degrees, defaults to 180 i.e. top to bottom)")
+	fmt.Println("                           - radial:#000080ff:#000000ff (centre to corners)")
+	fmt.Println("                           - image:<file>[:cover|stretch|tile] (PNG, JPEG or WebP, defaults to cover)")
 	fmt.Println()
 	fmt.Println("    --grid <gridspec>      Grid specification for an optional rectilinear grid, in the form type:colour:size:overlay, e.g.")
 	fmt.Println("                           - none")
 	fmt.Println("                           - square:#008000ff:~64")
 	fmt.Println("                           - rectangle:#008000ff:~64x48:overlay")
+	fmt.Println("                           - timeline:#008000ff:~64:labels:db")
+	fmt.Println("                           - db:#008000ff:~64:labels")
 	fmt.Println()
 	fmt.Println("                           The size may preceded by a 'fit':")
 	fmt.Println("                           - ~  approximate")
@@ -452,6 +529,10 @@ func help() {
 	fmt.Println("                           - >  greater than")
 	fmt.Println("                           - <  less than")
 	fmt.Println()
+	fmt.Println("                           A 'timeline' grid places the vertical lines at round time intervals (1s, 5s, 1m, ...) 'size'")
+	fmt.Println("                           pixels apart (according to the fit), with optional time :labels and an :amplitude or :db vertical scale.")
+	fmt.Println("                           A 'db' grid places the horizontal lines at -6dB intervals, with optional dB :labels.")
+	fmt.Println()
 	fmt.Println("                           If gridspec includes :overlay, the grid is rendered 'in front' of the waveform.")
 	fmt.Println()
 	fmt.Println("                           The default gridspec is 'square:#008000ff:~64'")
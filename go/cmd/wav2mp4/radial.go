@@ -0,0 +1,35 @@
+package main
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/math/f64"
+
+	"github.com/transcriptaze/wav2png/go/renderers/radial"
+)
+
+// arm draws the cursor as the 'arm' of a radial waveform, rotated clockwise from 12 o'clock by the
+// cursor position (0.0 to 1.0) with the base of the cursor at the centre of the waveform.
+func arm(img *image.NRGBA, cursor image.Image, bounds image.Rectangle, position float64) {
+	cx, cy, _ := radial.Geometry(bounds.Dx(), bounds.Dy())
+	cw := float64(cursor.Bounds().Dx())
+	ch := float64(cursor.Bounds().Dy())
+
+	theta := 2 * math.Pi * position
+	sin := math.Sin(theta)
+	cos := math.Cos(theta)
+
+	x := float64(bounds.Min.X) + cx
+	y := float64(bounds.Min.Y) + cy
+	tx := x - (cos*cw/2 - sin*ch)
+	ty := y - (sin*cw/2 + cos*ch)
+
+	transform := f64.Aff3{
+		cos, -sin, tx,
+		sin, cos, ty,
+	}
+
+	draw.BiLinear.Transform(img, transform, cursor, cursor.Bounds(), draw.Over, nil)
+}
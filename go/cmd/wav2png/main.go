@@ -15,12 +15,15 @@ import (
 	"github.com/transcriptaze/wav2png/go/audio"
 	"github.com/transcriptaze/wav2png/go/compositor"
 	"github.com/transcriptaze/wav2png/go/encoding"
+	"github.com/transcriptaze/wav2png/go/peaks"
 	"github.com/transcriptaze/wav2png/go/styles"
 )
 
 const VERSION = "v1.2.0"
 
 var opts = struct {
+	command string
+
 	out   string
 	start time.Duration
 	end   time.Duration
@@ -36,6 +39,15 @@ var opts = struct {
 	fill  styles.Fill
 	grid  styles.Grid
 
+	tile   uint
+	pps    float64
+	levels uint
+
+	format string
+	spp    uint
+	bits   uint
+	rms    bool
+
 	debug bool
 }{
 	out:    "",
@@ -61,6 +73,15 @@ var opts = struct {
 		WH:     "~64x48",
 	},
 
+	tile:   0,
+	pps:    100.0,
+	levels: 0,
+
+	format: "",
+	spp:    256,
+	bits:   8,
+	rms:    false,
+
 	debug: false,
 }
 
@@ -78,6 +99,8 @@ func main() {
 	var wavfile string
 	var outfile string
 	var audio []float32
+	var fs float64
+	var from time.Duration
 	var style styles.Style
 	var err error
 
@@ -93,11 +116,37 @@ func main() {
 		exit(err)
 	} else if style, err = makeStyle(); err != nil {
 		exit(err)
-	} else if audio, err = getAudio(wavfile); err != nil {
+	} else if audio, fs, from, err = getAudio(wavfile, style); err != nil {
 		exit(err)
 	}
 
-	if img, err := render(audio, style); err != nil {
+	style = style.WithSampleRate(fs)
+
+	if opts.command == "peaks" {
+		if err := exportPeaks(audio, fs, outfile); err != nil {
+			exit(err)
+		}
+
+		return
+	}
+
+	if opts.command == "pyramid" {
+		if err := pyramid(audio, fs, from, style, outfile); err != nil {
+			exit(err)
+		}
+
+		return
+	}
+
+	if opts.tile > 0 {
+		if err := tiles(audio, fs, from, style, outfile); err != nil {
+			exit(err)
+		}
+
+		return
+	}
+
+	if img, err := render(audio, fs, from, style); err != nil {
 		exit(err)
 	} else if err := write(img, outfile); err != nil {
 		exit(err)
@@ -116,8 +165,22 @@ func parse() (string, error) {
 	flag.DurationVar(&opts.start, "start", 0, "start time of audio selection")
 	flag.DurationVar(&opts.end, "end", 1*time.Hour, "end time of audio selection")
 	flag.Var(&opts.mix, "mix", "channel mix")
+	flag.UintVar(&opts.tile, "tile", opts.tile, "Tile width (pixels)")
+	flag.Float64Var(&opts.pps, "pps", opts.pps, "Tile pixels per second")
+	flag.UintVar(&opts.levels, "levels", opts.levels, "Number of pyramid zoom levels")
+	flag.StringVar(&opts.format, "format", opts.format, "Peaks file format (json or dat)")
+	flag.UintVar(&opts.spp, "spp", opts.spp, "Peaks samples per pixel")
+	flag.UintVar(&opts.bits, "bits", opts.bits, "Peaks resolution (8 or 16 bits)")
+	flag.BoolVar(&opts.rms, "rms", opts.rms, "Includes RMS values in JSON peaks")
 	flag.BoolVar(&opts.debug, "debug", opts.debug, "Displays diagnostic information")
-	flag.Parse()
+
+	args := os.Args[1:]
+	if len(args) > 0 && (args[0] == "pyramid" || args[0] == "peaks") {
+		opts.command = args[0]
+		args = args[1:]
+	}
+
+	flag.CommandLine.Parse(args)
 
 	if len(flag.Args()) < 1 {
 		return "", fmt.Errorf("missing WAV file")
@@ -185,10 +248,14 @@ func makeStyle() (style styles.Style, err error) {
 	return
 }
 
-func getAudio(file string) (pcm []float32, err error) {
+func getAudio(file string, style styles.Style) (pcm []float32, fs float64, from time.Duration, err error) {
 	var f *os.File
 	var audio encoding.Audio
 
+	if ext := strings.ToLower(filepath.Ext(file)); ext == ".dat" || ext == ".json" {
+		return getPeaks(file, style)
+	}
+
 	if f, err = os.Open(file); err != nil {
 		return
 	}
@@ -199,7 +266,7 @@ func getAudio(file string) (pcm []float32, err error) {
 		return
 	}
 
-	from := 0 * time.Second
+	from = 0 * time.Second
 	to := audio.Duration
 
 	flag.Visit(func(f *flag.Flag) {
@@ -221,7 +288,7 @@ func getAudio(file string) (pcm []float32, err error) {
 		fmt.Println()
 	}
 
-	fs := audio.SampleRate
+	fs = audio.SampleRate
 	samples := mix(audio, opts.mix.Channels()...)
 	start := int(math.Floor(from.Seconds() * fs))
 	end := int(math.Floor(to.Seconds() * fs))
@@ -231,8 +298,61 @@ func getAudio(file string) (pcm []float32, err error) {
 	return
 }
 
-func render(audio []float32, style styles.Style) (*image.NRGBA, error) {
-	compositor := compositor.FromStyle(style)
+func getPeaks(file string, style styles.Style) (pcm []float32, fs float64, from time.Duration, err error) {
+	var f *os.File
+	var p *peaks.Peaks
+
+	if opts.command == "peaks" {
+		err = fmt.Errorf("cannot generate peaks from a peaks file")
+		return
+	}
+
+	if f, err = os.Open(file); err != nil {
+		return
+	}
+
+	defer f.Close()
+
+	if p, err = peaks.Decode(f); err != nil {
+		return
+	}
+
+	from = 0 * time.Second
+	to := p.Duration()
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "start" && opts.start < p.Duration() {
+			from = opts.start
+		} else if f.Name == "end" && opts.end < p.Duration() {
+			to = opts.end
+		}
+	})
+
+	if opts.debug {
+		fmt.Println()
+		fmt.Printf("   File:        %v\n", file)
+		fmt.Printf("   Sample Rate: %v\n", p.SampleRate)
+		fmt.Printf("   Resolution:  %v bits\n", p.Bits)
+		fmt.Printf("   Samples/px:  %v\n", p.SamplesPerPixel)
+		fmt.Printf("   Duration:    %v\n", p.Duration())
+		fmt.Printf("   Peaks:       %v\n", p.Length())
+		fmt.Println()
+	}
+
+	// ... at least one min/max pair per pixel
+	pixels := int(style.Width())
+	if opts.command == "pyramid" || opts.tile > 0 {
+		pixels = int(math.Ceil((to - from).Seconds() * opts.pps))
+	}
+
+	pcm, fs = p.Slice(from, to).Samples(2 * pixels)
+
+	return
+}
+
+func render(audio []float32, fs float64, from time.Duration, style styles.Style) (*image.NRGBA, error) {
+	to := from + time.Duration(float64(len(audio))/fs*float64(time.Second))
+	compositor := compositor.FromStyle(style).WithTimeRange(from, to)
 
 	return compositor.Render(audio)
 }
@@ -271,7 +391,9 @@ func mix(wav encoding.Audio, channels ...int) []float32 {
 
 func usage() {
 	fmt.Println()
-	fmt.Println("   Usage: wav2png [--debug] [--style <file>] [--height <height>] [--width <width>] [--padding <padding>] [--scale <scale>] [--out <filepath>] <filename>")
+	fmt.Println("   Usage: wav2png [--debug] [--style <file>] [--height <height>] [--width <width>] [--padding <padding>] [--scale <scale>] [--tile <width> [--pps <pixels>]] [--out <filepath>] <filename>")
+	fmt.Println("          wav2png pyramid [--debug] [--style <file>] [--tile <width>] [--pps <pixels>] [--levels <levels>] [--out <directory>] <filename>")
+	fmt.Println("          wav2png peaks [--debug] [--format json|dat] [--spp <samples>] [--bits 8|16] [--rms] [--out <filepath>] <filename>")
 	fmt.Println()
 }
 
@@ -280,7 +402,8 @@ func help() {
 	fmt.Println("   Usage: wav2png [--debug] [--height <height>] [--width <width>] [--padding <padding>] [--out <filepath>] <filename>")
 	fmt.Println()
 	fmt.Println()
-	fmt.Println("       <wav>         WAV file to render.")
+	fmt.Println("       <wav>         WAV file to render. May also be an audiowaveform peaks file (.dat or .json), in which")
+	fmt.Println("                     case the waveform is rendered from the precomputed min/max peaks.")
 	fmt.Println()
 	fmt.Println("       --out <path>  File path for MP4 file - if <path> is a directory, the WAV file name is")
 	fmt.Println("                     used and defaults to the WAV file base path. wav2mp4 generates a set of ffmpeg frames ")
@@ -309,13 +432,20 @@ func help() {
 	fmt.Println("    --palette  <palette>   (legacy) Palette used to colour the waveform. May be the name of one of the internal colour")
 	fmt.Println("                           palettes or a user provided PNG file. Defaults to 'ice'")
 	fmt.Println()
-	fmt.Println("    --fill <fillspec>      (legacy) Fill specification for the background colour, in the form type:colour e.g. solid:#0000ffff")
+	fmt.Println("    --fill <fillspec>      (legacy) Fill specification for the background, in the form type:colour[:...] e.g.")
+	fmt.Println("                           - none (transparent background)")
+	fmt.Println("                           - solid:#0000ffff")
+	fmt.Println("                           - linear:#000000ff:#000080ff[:angle] (angle in degrees, defaults to 180 i.e. top to bottom)")
+	fmt.Println("                           - radial:#000080ff:#000000ff (centre to corners)")
+	fmt.Println("                           - image:<file>[:cover|stretch|tile] (PNG, JPEG or WebP, defaults to cover)")
 	fmt.Println()
 	fmt.Println("    --grid <gridspec>      (legacy) Grid specification for an optional rectilinear grid, in the form type:colour:size:overlay")
 	fmt.Println("                           e.g.")
 	fmt.Println("                           - none")
 	fmt.Println("                           - square:#008000ff:~64")
 	fmt.Println("                           - rectangle:#008000ff:~64x48:overlay")
+	fmt.Println("                           - timeline:#008000ff:~64:labels:db")
+	fmt.Println("                           - db:#008000ff:~64:labels")
 	fmt.Println()
 	fmt.Println("                           The size may preceded by a 'fit':")
 	fmt.Println("                           - ~  approximate")
@@ -325,6 +455,10 @@ func help() {
 	fmt.Println("                           - >  greater than")
 	fmt.Println("                           - <  less than")
 	fmt.Println()
+	fmt.Println("                           A 'timeline' grid places the vertical lines at round time intervals (1s, 5s, 1m, ...) 'size'")
+	fmt.Println("                           pixels apart (according to the fit), with optional time :labels and an :amplitude or :db vertical scale.")
+	fmt.Println("                           A 'db' grid places the horizontal lines at -6dB intervals, with optional dB :labels.")
+	fmt.Println()
 	fmt.Println("                           If gridspec includes :overlay, the grid is rendered 'in front' of the waveform.")
 	fmt.Println()
 	fmt.Println("                           The default gridspec is 'square:#008000ff:~64'")
@@ -350,6 +484,41 @@ func help() {
 	fmt.Println("    --end <time>           The end time of the segment of audio to render, in Go time format (e.g. 10s or 1m5s)")
 	fmt.Println("                           Defaults to the end of the audio.")
 	fmt.Println()
+	fmt.Println("    --tile <pixels>        (optional) Splits the rendered timeline into tiles of the given width (e.g. 2048), written")
+	fmt.Println("                           as <name>-0000.png, <name>-0001.png, ... along with a <name>.json manifest with the time")
+	fmt.Println("                           range of each tile. The height, padding, etc. are taken from the style.")
+	fmt.Println()
+	fmt.Println("    --pps <pixels>         (optional) Number of pixels per second of audio for tiled rendering. Defaults to 100.")
+	fmt.Println()
+	fmt.Println()
+	fmt.Println("   Usage: wav2png pyramid [--debug] [options] [--tile <width>] [--pps <pixels>] [--levels <levels>] [--out <directory>] <filename>")
+	fmt.Println()
+	fmt.Println("       Renders a multi-resolution 'zoom pyramid' of tiles laid out as <directory>/<zoom>/<tile>.png, along with a")
+	fmt.Println("       <directory>/manifest.json file describing the zoom levels and tiles. Zoom level 0 is the most 'zoomed out'")
+	fmt.Println("       level, with each subsequent level doubling the pixels per second up to the --pps value at the highest level.")
+	fmt.Println()
+	fmt.Println("    --tile <pixels>        (optional) Tile width. Defaults to 2048.")
+	fmt.Println()
+	fmt.Println("    --pps <pixels>         (optional) Number of pixels per second of audio at the highest zoom level. Defaults to 100.")
+	fmt.Println()
+	fmt.Println("    --levels <levels>      (optional) Number of zoom levels. Defaults to the number of levels required for the entire")
+	fmt.Println("                           audio to fit in a single tile at zoom level 0.")
+	fmt.Println()
+	fmt.Println()
+	fmt.Println("   Usage: wav2png peaks [--debug] [--format json|dat] [--spp <samples>] [--bits 8|16] [--rms] [--out <filepath>] <filename>")
+	fmt.Println()
+	fmt.Println("       Generates min/max peak data in the audiowaveform JSON or binary (.dat) format e.g. for use with peaks.js.")
+	fmt.Println()
+	fmt.Println("    --format <format>      (optional) Peaks file format ('json' or 'dat'). Defaults to the --out file extension,")
+	fmt.Println("                           falling back to 'json'.")
+	fmt.Println()
+	fmt.Println("    --spp <samples>        (optional) Number of audio samples per peak. Defaults to 256.")
+	fmt.Println()
+	fmt.Println("    --bits <bits>          (optional) Peak resolution (8 or 16 bits). Defaults to 8.")
+	fmt.Println()
+	fmt.Println("    --rms                  (optional) Includes the RMS value of each peak as an additional 'rms' field in JSON")
+	fmt.Println("                           peaks files. Not supported for the binary format.")
+	fmt.Println()
 }
 
 func version() {
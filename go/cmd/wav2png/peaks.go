@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/transcriptaze/wav2png/go/peaks"
+)
+
+func exportPeaks(audio []float32, fs float64, outfile string) (err error) {
+	format := strings.ToLower(opts.format)
+	ext := strings.ToLower(filepath.Ext(outfile))
+
+	if format == "" {
+		format = "json"
+		if ext == ".dat" {
+			format = "dat"
+		}
+	}
+
+	if format != "json" && format != "dat" {
+		return fmt.Errorf("invalid peaks format (%v)", opts.format)
+	} else if format == "dat" && opts.rms {
+		return fmt.Errorf("RMS peaks are not supported by the binary format")
+	}
+
+	if ext == ".png" {
+		outfile = strings.TrimSuffix(outfile, filepath.Ext(outfile)) + "." + format
+	}
+
+	p, err := peaks.Compute(audio, fs, int(opts.spp), int(opts.bits), opts.rms)
+	if err != nil {
+		return err
+	}
+
+	if opts.debug {
+		fmt.Printf("   Peaks:       %v\n", outfile)
+		fmt.Printf("     format:    %v\n", format)
+		fmt.Printf("     spp:       %v\n", p.SamplesPerPixel)
+		fmt.Printf("     bits:      %v\n", p.Bits)
+		fmt.Printf("     length:    %v\n", p.Length())
+		fmt.Println()
+	}
+
+	f, err := os.Create(outfile)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := f.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+
+	if format == "dat" {
+		return p.EncodeDat(f)
+	}
+
+	return p.EncodeJSON(f)
+}
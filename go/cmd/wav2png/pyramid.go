@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/compositor"
+	"github.com/transcriptaze/wav2png/go/styles"
+)
+
+type pyramidManifest struct {
+	Width  int            `json:"width"`
+	Height int            `json:"height"`
+	Start  float64        `json:"start"`
+	End    float64        `json:"end"`
+	Levels []pyramidLevel `json:"levels"`
+}
+
+type pyramidLevel struct {
+	Zoom  int            `json:"zoom"`
+	PPS   float64        `json:"pps"`
+	Tiles []manifestTile `json:"tiles"`
+}
+
+func pyramid(audio []float32, fs float64, from time.Duration, style styles.Style, outfile string) error {
+	width := 2048
+	if opts.tile > 0 {
+		width = int(opts.tile)
+	}
+
+	dir := strings.TrimSuffix(outfile, filepath.Ext(outfile))
+	levels, err := compositor.Pyramid(len(audio), fs, opts.pps, width, int(opts.levels))
+	if err != nil {
+		return err
+	}
+
+	to := from + time.Duration(float64(len(audio))/fs*float64(time.Second))
+	m := pyramidManifest{
+		Width:  width,
+		Height: int(style.Height()),
+		Start:  from.Seconds(),
+		End:    to.Seconds(),
+		Levels: []pyramidLevel{},
+	}
+
+	for _, level := range levels {
+		if err := os.MkdirAll(filepath.Join(dir, fmt.Sprintf("%v", level.Zoom)), 0777); err != nil {
+			return err
+		}
+
+		m.Levels = append(m.Levels, pyramidLevel{
+			Zoom:  level.Zoom,
+			PPS:   level.PPS,
+			Tiles: []manifestTile{},
+		})
+	}
+
+	c := compositor.FromStyle(style).WithTimeRange(from, to)
+	err = c.RenderPyramid(audio, levels, func(level compositor.Level, tile compositor.Tile, img *image.NRGBA) error {
+		file := filepath.Join(fmt.Sprintf("%v", level.Zoom), fmt.Sprintf("%v.png", tile.Index))
+
+		if err := write(img, filepath.Join(dir, file)); err != nil {
+			return err
+		}
+
+		if opts.debug {
+			fmt.Printf("   ... tile %-3d %-5d  %-8v %-8v %v\n", level.Zoom, tile.Index, (from + tile.Start).Round(time.Millisecond), (from + tile.End).Round(time.Millisecond), file)
+		}
+
+		m.Levels[level.Zoom].Tiles = append(m.Levels[level.Zoom].Tiles, manifestTile{
+			File:  filepath.ToSlash(file),
+			X:     tile.X,
+			Width: tile.Width,
+			Start: (from + tile.Start).Seconds(),
+			End:   (from + tile.End).Seconds(),
+		})
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if bytes, err := json.MarshalIndent(m, "", "  "); err != nil {
+		return err
+	} else {
+		return os.WriteFile(filepath.Join(dir, "manifest.json"), bytes, 0666)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/compositor"
+	"github.com/transcriptaze/wav2png/go/styles"
+)
+
+type manifest struct {
+	PPS    float64        `json:"pps"`
+	Width  int            `json:"width"`
+	Height int            `json:"height"`
+	Start  float64        `json:"start"`
+	End    float64        `json:"end"`
+	Tiles  []manifestTile `json:"tiles"`
+}
+
+type manifestTile struct {
+	File  string  `json:"file"`
+	X     int     `json:"x"`
+	Width int     `json:"width"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
+
+func tiles(audio []float32, fs float64, from time.Duration, style styles.Style, outfile string) error {
+	width := int(opts.tile)
+	pps := opts.pps
+	base := strings.TrimSuffix(outfile, filepath.Ext(outfile))
+
+	tiles, err := compositor.Tiles(len(audio), fs, pps, width)
+	if err != nil {
+		return err
+	}
+
+	to := from + time.Duration(float64(len(audio))/fs*float64(time.Second))
+	c := compositor.FromStyle(style).WithTimeRange(from, to)
+	m := manifest{
+		PPS:    pps,
+		Width:  width,
+		Height: int(style.Height()),
+		Start:  from.Seconds(),
+		End:    to.Seconds(),
+		Tiles:  []manifestTile{},
+	}
+
+	for _, tile := range tiles {
+		file := fmt.Sprintf("%v-%04d.png", base, tile.Index)
+
+		if img, err := c.RenderTile(audio, tile); err != nil {
+			return err
+		} else if err := write(img, file); err != nil {
+			return err
+		}
+
+		if opts.debug {
+			fmt.Printf("   ... tile %-5d  %-8v %-8v %v\n", tile.Index, (from + tile.Start).Round(time.Millisecond), (from + tile.End).Round(time.Millisecond), file)
+		}
+
+		m.Tiles = append(m.Tiles, manifestTile{
+			File:  filepath.Base(file),
+			X:     tile.X,
+			Width: tile.Width,
+			Start: (from + tile.Start).Seconds(),
+			End:   (from + tile.End).Seconds(),
+		})
+	}
+
+	if bytes, err := json.MarshalIndent(m, "", "  "); err != nil {
+		return err
+	} else {
+		return os.WriteFile(base+".json", bytes, 0666)
+	}
+}
@@ -2,34 +2,40 @@ package compositor
 
 import (
 	"image"
+	"time"
 
 	"golang.org/x/image/draw"
 
 	"github.com/transcriptaze/wav2png/go/fills"
 	"github.com/transcriptaze/wav2png/go/grids"
+	"github.com/transcriptaze/wav2png/go/peaks"
 	"github.com/transcriptaze/wav2png/go/renderers"
 	"github.com/transcriptaze/wav2png/go/styles"
 )
 
 type Compositor struct {
-	width      uint
-	height     uint
-	padding    int
-	scale      float64
-	background fills.FillSpec
-	grid       grids.GridSpec
-	renderer   renderers.Renderer
+	width       uint
+	height      uint
+	padding     int
+	scale       float64
+	supersample uint
+	background  fills.FillSpec
+	grid        grids.GridSpec
+	renderer    renderers.Renderer
+	start       time.Duration
+	end         time.Duration
 }
 
 func FromStyle(style styles.Style) Compositor {
 	return Compositor{
-		width:      style.Width(),
-		height:     style.Height(),
-		padding:    style.Padding(),
-		scale:      style.Scale().Vertical,
-		background: style.Fill(),
-		grid:       style.Grid(),
-		renderer:   style.Renderer(),
+		width:       style.Width(),
+		height:      style.Height(),
+		padding:     style.Padding(),
+		scale:       style.Scale().Vertical,
+		supersample: style.Supersample(),
+		background:  style.Fill(),
+		grid:        style.Grid(),
+		renderer:    style.Renderer(),
 	}
 }
 
@@ -45,6 +51,15 @@ func NewCompositor(width uint, height uint, padding int, scale float64, backgrou
 	}
 }
 
+// WithTimeRange returns a copy of the compositor with the time range of the rendered audio, for
+// time aligned grids.
+func (c Compositor) WithTimeRange(start, end time.Duration) Compositor {
+	c.start = start
+	c.end = end
+
+	return c
+}
+
 func (c Compositor) Render(samples []float32) (*image.NRGBA, error) {
 	width := int(c.width)
 	height := int(c.height)
@@ -52,7 +67,20 @@ func (c Compositor) Render(samples []float32) (*image.NRGBA, error) {
 	scale := c.scale
 
 	img := image.NewNRGBA(image.Rect(0, 0, width, height))
-	grid := grids.Grid(c.grid, width, height, padding)
+	spec := grids.WithScale(grids.WithTimeRange(c.grid, c.start, c.end), scale)
+
+	if N := int(min(c.supersample, styles.MAX_SUPERSAMPLE)); N > 1 {
+		if layers, err := c.supersampled(samples, spec, width, height, padding, scale, N); err != nil {
+			return nil, err
+		} else {
+			fills.Fill(img, c.background)
+			draw.Draw(img, img.Bounds(), layers, image.Pt(0, 0), draw.Over)
+
+			return img, nil
+		}
+	}
+
+	under, over := grids.Layers(spec, width, height, padding, 1)
 
 	if waveform, err := c.renderer.Render(samples, width, height, padding, scale); err != nil {
 		return nil, err
@@ -62,14 +90,43 @@ func (c Compositor) Render(samples []float32) (*image.NRGBA, error) {
 
 		fills.Fill(img, c.background)
 
-		if c.grid.Overlay() {
-			draw.Draw(img, bounds, waveform, origin, draw.Over)
-			draw.Draw(img, bounds, grid, origin, draw.Over)
-		} else {
-			draw.Draw(img, bounds, grid, origin, draw.Over)
-			draw.Draw(img, bounds, waveform, origin, draw.Over)
-		}
+		draw.Draw(img, bounds, under, origin, draw.Over)
+		draw.Draw(img, bounds, waveform, origin, draw.Over)
+		draw.Draw(img, bounds, over, origin, draw.Over)
 
 		return img, nil
 	}
 }
+
+// supersampled renders the grid and waveform at N times the image size and downsamples the
+// composited grid and waveform to the image size with a Catmull-Rom filter. The grid lines
+// are scaled by N so that they downsample to the same (1 pixel) lines as for an unsupersampled
+// image.
+func (c Compositor) supersampled(samples []float32, spec grids.GridSpec, width, height, padding int, scale float64, N int) (*image.NRGBA, error) {
+	under, over := grids.Layers(spec, width, height, padding, N)
+
+	if waveform, err := c.renderer.Render(samples, N*width, N*height, N*padding, scale); err != nil {
+		return nil, err
+	} else {
+		img := image.NewNRGBA(image.Rect(0, 0, N*width, N*height))
+		origin := image.Pt(0, 0)
+		bounds := img.Bounds()
+
+		draw.Draw(img, bounds, under, origin, draw.Over)
+		draw.Draw(img, bounds, waveform, origin, draw.Over)
+		draw.Draw(img, bounds, over, origin, draw.Over)
+
+		layers := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(layers, layers.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+		return layers, nil
+	}
+}
+
+// RenderPeaks renders precomputed min/max peak data (e.g. an audiowaveform .dat or .json file) with
+// the compositor renderer, in place of the raw audio samples.
+func (c Compositor) RenderPeaks(p peaks.Peaks) (*image.NRGBA, error) {
+	samples, _ := p.Samples(2 * int(c.width))
+
+	return c.Render(samples)
+}
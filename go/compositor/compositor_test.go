@@ -19,6 +19,7 @@ import (
 	"github.com/transcriptaze/wav2png/go/grids"
 	"github.com/transcriptaze/wav2png/go/kernels"
 	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/peaks"
 	"github.com/transcriptaze/wav2png/go/renderers/columns"
 	"github.com/transcriptaze/wav2png/go/renderers/lines"
 )
@@ -144,3 +145,162 @@ func encode(img *image.NRGBA) []byte {
 
 	return b.Bytes()
 }
+
+func TestRenderPeaks(t *testing.T) {
+	compositor := Compositor{
+		width:      640,
+		height:     480,
+		padding:    0,
+		scale:      1.0,
+		background: fills.NewSolidFill(black),
+		grid:       grids.NewNoGrid(),
+
+		renderer: lines.Lines{
+			Palette:   palettes.Fire,
+			AntiAlias: kernels.None,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	p, err := peaks.Compute(samples, audio.SampleRate, 256, 16, false)
+	if err != nil {
+		t.Fatalf("error computing peaks (%v)", err)
+	}
+
+	img, err := compositor.RenderPeaks(*p)
+	if err != nil {
+		t.Fatalf("error rendering peaks (%v)", err)
+	}
+
+	// ... peaks should span the same vertical extent as the raw audio
+	reference, _ := compositor.Render(samples)
+	extent := func(img *image.NRGBA) (int, int) {
+		top := img.Bounds().Max.Y
+		bottom := 0
+		for y := 0; y < img.Bounds().Dy(); y++ {
+			for x := 0; x < img.Bounds().Dx(); x++ {
+				if img.NRGBAAt(x, y) != black && y < top {
+					top = y
+				}
+
+				if img.NRGBAAt(x, y) != black && y > bottom {
+					bottom = y
+				}
+			}
+		}
+
+		return top, bottom
+	}
+
+	p0, q0 := extent(reference)
+	p1, q1 := extent(img)
+	if p1-p0 > 1 || p0-p1 > 1 || q1-q0 > 1 || q0-q1 > 1 {
+		t.Errorf("incorrect peaks waveform extent - expected:%v-%v, got:%v-%v", p0, q0, p1, q1)
+	}
+}
+
+func TestSupersample(t *testing.T) {
+	compositor := Compositor{
+		width:       320,
+		height:      240,
+		padding:     0,
+		scale:       1.0,
+		supersample: 4,
+		background:  fills.NewSolidFill(black),
+		grid:        grids.NewNoGrid(),
+
+		renderer: lines.Lines{
+			Palette:   palettes.Fire,
+			AntiAlias: kernels.None,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	img, err := compositor.Render(samples)
+	if err != nil {
+		t.Fatalf("error rendering supersampled image (%v)", err)
+	}
+
+	if img.Bounds() != image.Rect(0, 0, 320, 240) {
+		t.Fatalf("incorrect supersampled image size - expected:%v, got:%v", image.Rect(0, 0, 320, 240), img.Bounds())
+	}
+
+	// ... waveform should span (approximately) the same vertical extent as the non-supersampled image
+	compositor.supersample = 1
+	reference, _ := compositor.Render(samples)
+
+	extent := func(img *image.NRGBA) (int, int) {
+		top := img.Bounds().Max.Y
+		bottom := 0
+		for y := 0; y < img.Bounds().Dy(); y++ {
+			for x := 0; x < img.Bounds().Dx(); x++ {
+				if c := img.NRGBAAt(x, y); (c.R > 0x20 || c.G > 0x20 || c.B > 0x20) && y < top {
+					top = y
+				}
+
+				if c := img.NRGBAAt(x, y); (c.R > 0x20 || c.G > 0x20 || c.B > 0x20) && y > bottom {
+					bottom = y
+				}
+			}
+		}
+
+		return top, bottom
+	}
+
+	p0, q0 := extent(reference)
+	p1, q1 := extent(img)
+	if p1-p0 > 2 || p0-p1 > 2 || q1-q0 > 2 || q0-q1 > 2 {
+		t.Errorf("incorrect supersampled waveform extent - expected:%v-%v, got:%v-%v", p0, q0, p1, q1)
+	}
+}
+
+func TestTransparentBackground(t *testing.T) {
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	compositor := Compositor{
+		width:      320,
+		height:     240,
+		padding:    0,
+		scale:      1.0,
+		background: fills.NewNoFill(),
+		grid:       grids.NewNoGrid(),
+
+		renderer: lines.Lines{
+			Palette:   palettes.NewPalette("white", []color.NRGBA{white}),
+			AntiAlias: kernels.Soft,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	img, err := compositor.Render(samples)
+	if err != nil {
+		t.Fatalf("error rendering transparent image (%v)", err)
+	}
+
+	if c := img.NRGBAAt(0, 0); c.A != 0 {
+		t.Errorf("expected transparent background - got:%v", c)
+	}
+
+	// ... anti-aliased edges should be partially transparent white, not darkened by the transparent background
+	translucent := 0
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if c := img.NRGBAAt(x, y); c.A > 0 && c.A < 0xff {
+				translucent++
+				if c.R < 0xfe || c.G < 0xfe || c.B < 0xfe {
+					t.Fatalf("incorrect anti-aliased colour at (%v,%v) - expected:%v, got:%v", x, y, white, c)
+				}
+			}
+		}
+	}
+
+	if translucent == 0 {
+		t.Errorf("expected partially transparent anti-aliased pixels")
+	}
+}
@@ -0,0 +1,70 @@
+package compositor
+
+import (
+	"fmt"
+	"image"
+	"math"
+)
+
+// Level is a single zoom level of a tile pyramid. Level 0 is the most 'zoomed out' level, with
+// each subsequent level having twice the pixels per second of the preceding level.
+type Level struct {
+	Zoom  int
+	PPS   float64
+	Tiles []Tile
+}
+
+// Pyramid lays out the tiles for a set of zoom levels, with the highest zoom level rendered at pps
+// pixels per second and each lower level halving the pixels per second. If levels is 0 the number of
+// levels is chosen so that the entire timeline fits into a single tile at level 0.
+func Pyramid(N int, fs float64, pps float64, width int, levels int) ([]Level, error) {
+	if fs <= 0 {
+		return nil, fmt.Errorf("invalid sample rate (%v)", fs)
+	} else if pps <= 0 {
+		return nil, fmt.Errorf("invalid pixels per second (%v)", pps)
+	} else if width <= 0 {
+		return nil, fmt.Errorf("invalid tile width (%v)", width)
+	} else if levels < 0 {
+		return nil, fmt.Errorf("invalid number of zoom levels (%v)", levels)
+	}
+
+	if levels == 0 {
+		levels = 1
+		if pixels := math.Ceil(float64(N) * pps / fs); pixels > float64(width) {
+			levels += int(math.Ceil(math.Log2(pixels / float64(width))))
+		}
+	}
+
+	pyramid := make([]Level, levels)
+	for z := 0; z < levels; z++ {
+		p := pps / math.Pow(2, float64(levels-1-z))
+
+		if tiles, err := Tiles(N, fs, p, width); err != nil {
+			return nil, err
+		} else {
+			pyramid[z] = Level{
+				Zoom:  z,
+				PPS:   p,
+				Tiles: tiles,
+			}
+		}
+	}
+
+	return pyramid, nil
+}
+
+// RenderPyramid renders every tile at every level of the pyramid, invoking the callback function
+// with each rendered tile. Rendering stops at the first error returned by the callback.
+func (c Compositor) RenderPyramid(samples []float32, pyramid []Level, f func(Level, Tile, *image.NRGBA) error) error {
+	for _, level := range pyramid {
+		for _, tile := range level.Tiles {
+			if img, err := c.RenderTile(samples, tile); err != nil {
+				return err
+			} else if err := f(level, tile, img); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package compositor
+
+import (
+	"image"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/fills"
+	"github.com/transcriptaze/wav2png/go/grids"
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers/lines"
+)
+
+func TestPyramid(t *testing.T) {
+	expected := []struct {
+		pps   float64
+		tiles int
+	}{
+		{25, 1},
+		{50, 2},
+		{100, 3},
+	}
+
+	pyramid, err := Pyramid(1102500, 44100, 100, 1000, 0)
+	if err != nil {
+		t.Fatalf("error creating pyramid (%v)", err)
+	}
+
+	if len(pyramid) != len(expected) {
+		t.Fatalf("incorrect number of levels - expected:%v, got:%v", len(expected), len(pyramid))
+	}
+
+	for i, v := range expected {
+		level := pyramid[i]
+		if level.Zoom != i {
+			t.Errorf("incorrect zoom for level %v - expected:%v, got:%v", i, i, level.Zoom)
+		}
+
+		if level.PPS != v.pps {
+			t.Errorf("incorrect pixels per second for level %v - expected:%v, got:%v", i, v.pps, level.PPS)
+		}
+
+		if len(level.Tiles) != v.tiles {
+			t.Errorf("incorrect number of tiles for level %v - expected:%v, got:%v", i, v.tiles, len(level.Tiles))
+		}
+	}
+}
+
+func TestPyramidWithLevels(t *testing.T) {
+	pyramid, err := Pyramid(1102500, 44100, 100, 1000, 2)
+	if err != nil {
+		t.Fatalf("error creating pyramid (%v)", err)
+	}
+
+	if len(pyramid) != 2 {
+		t.Fatalf("incorrect number of levels - expected:%v, got:%v", 2, len(pyramid))
+	}
+
+	if pyramid[0].PPS != 50 || pyramid[1].PPS != 100 {
+		t.Errorf("incorrect pixels per second - expected:%v, got:%v", []float64{50, 100}, []float64{pyramid[0].PPS, pyramid[1].PPS})
+	}
+}
+
+func TestRenderPyramid(t *testing.T) {
+	compositor := Compositor{
+		width:      640,
+		height:     120,
+		padding:    0,
+		scale:      1.0,
+		background: fills.NewSolidFill(black),
+		grid:       grids.NewNoGrid(),
+
+		renderer: lines.Lines{
+			Palette:   palettes.Fire,
+			AntiAlias: kernels.Vertical,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	pyramid, err := Pyramid(len(samples), audio.SampleRate, 512, 256, 0)
+	if err != nil {
+		t.Fatalf("error creating pyramid (%v)", err)
+	}
+
+	expected := 0
+	for _, level := range pyramid {
+		expected += len(level.Tiles)
+	}
+
+	rendered := 0
+	err = compositor.RenderPyramid(samples, pyramid, func(level Level, tile Tile, img *image.NRGBA) error {
+		if img.Bounds().Dx() != tile.Width {
+			t.Errorf("incorrect width for tile %v/%v - expected:%v, got:%v", level.Zoom, tile.Index, tile.Width, img.Bounds().Dx())
+		}
+
+		rendered++
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("error rendering pyramid (%v)", err)
+	} else if rendered != expected {
+		t.Errorf("incorrect number of rendered tiles - expected:%v, got:%v", expected, rendered)
+	}
+}
@@ -0,0 +1,90 @@
+package compositor
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/grids"
+)
+
+// Tile describes a fixed width segment of a waveform timeline rendered at a fixed number of
+// pixels per second. X is the offset of the tile (in pixels) from the start of the timeline and
+// Start and End are the tile time range relative to the start of the audio.
+type Tile struct {
+	Index int
+	X     int
+	Width int
+	Start time.Duration
+	End   time.Duration
+
+	from int
+	to   int
+}
+
+// Tiles splits a timeline of N samples (at a sample rate of fs) rendered at pps pixels per second
+// into tiles of the requested width. The final tile is truncated to the remaining width of the
+// timeline.
+func Tiles(N int, fs float64, pps float64, width int) ([]Tile, error) {
+	if fs <= 0 {
+		return nil, fmt.Errorf("invalid sample rate (%v)", fs)
+	} else if pps <= 0 {
+		return nil, fmt.Errorf("invalid pixels per second (%v)", pps)
+	} else if width <= 0 {
+		return nil, fmt.Errorf("invalid tile width (%v)", width)
+	}
+
+	tiles := []Tile{}
+	pixels := int(math.Ceil(float64(N) * pps / fs))
+
+	for x := 0; x < pixels; x += width {
+		w := width
+		if x+w > pixels {
+			w = pixels - x
+		}
+
+		from := int(math.Round(float64(x) * fs / pps))
+		to := int(math.Round(float64(x+w) * fs / pps))
+		if to > N {
+			to = N
+		}
+
+		tiles = append(tiles, Tile{
+			Index: len(tiles),
+			X:     x,
+			Width: w,
+			Start: seconds(float64(from) / fs),
+			End:   seconds(float64(to) / fs),
+
+			from: from,
+			to:   to,
+		})
+	}
+
+	return tiles, nil
+}
+
+// RenderTile renders the segment of the samples spanned by the tile as an image with the tile
+// width and the compositor height. The compositor time range (if any) is taken to start at the
+// first sample. Tiles are rendered without padding or a grid border and with the grid laid out from
+// the start of the timeline, so that the waveform and grid are continuous across adjacent tiles.
+func (c Compositor) RenderTile(samples []float32, tile Tile) (*image.NRGBA, error) {
+	if tile.from < 0 || tile.to > len(samples) || tile.from > tile.to {
+		return nil, fmt.Errorf("tile %d not in sample range 0-%v", tile.Index, len(samples))
+	}
+
+	elements := c.grid.Elements()
+	elements.Border = grids.Hidden
+
+	c.width = uint(tile.Width)
+	c.padding = 0
+	c.start, c.end = c.start+tile.Start, c.start+tile.End
+	c.grid = grids.WithOrigin(grids.WithElements(c.grid, elements), tile.X)
+
+	return c.Render(samples[tile.from:tile.to])
+}
+
+func seconds(g float64) time.Duration {
+	return time.Duration(g * float64(time.Second))
+}
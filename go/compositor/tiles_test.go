@@ -0,0 +1,134 @@
+package compositor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/fills"
+	"github.com/transcriptaze/wav2png/go/grids"
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers/lines"
+)
+
+func TestTiles(t *testing.T) {
+	expected := []Tile{
+		{Index: 0, X: 0, Width: 1000, Start: 0 * time.Second, End: 10 * time.Second, from: 0, to: 441000},
+		{Index: 1, X: 1000, Width: 1000, Start: 10 * time.Second, End: 20 * time.Second, from: 441000, to: 882000},
+		{Index: 2, X: 2000, Width: 500, Start: 20 * time.Second, End: 25 * time.Second, from: 882000, to: 1102500},
+	}
+
+	tiles, err := Tiles(1102500, 44100, 100, 1000)
+	if err != nil {
+		t.Fatalf("error creating tiles (%v)", err)
+	}
+
+	if !reflect.DeepEqual(tiles, expected) {
+		t.Errorf("incorrect tiles\n   expected:%v\n   got:     %v", expected, tiles)
+	}
+}
+
+func TestTilesWithPartialPixel(t *testing.T) {
+	tiles, err := Tiles(44101, 44100, 100, 64)
+	if err != nil {
+		t.Fatalf("error creating tiles (%v)", err)
+	}
+
+	if len(tiles) != 2 {
+		t.Fatalf("incorrect number of tiles - expected:%v, got:%v", 2, len(tiles))
+	}
+
+	if tile := tiles[1]; tile.Width != 37 || tile.to != 44101 {
+		t.Errorf("incorrect final tile - expected:%v, got:%v", "37px ending at sample 44101", tile)
+	}
+}
+
+func TestTilesWithInvalidArgs(t *testing.T) {
+	tests := []struct {
+		fs    float64
+		pps   float64
+		width int
+	}{
+		{0, 100, 1000},
+		{44100, 0, 1000},
+		{44100, 100, 0},
+	}
+
+	for _, v := range tests {
+		if _, err := Tiles(44100, v.fs, v.pps, v.width); err == nil {
+			t.Errorf("expected error for fs:%v, pps:%v, width:%v", v.fs, v.pps, v.width)
+		}
+	}
+}
+
+func TestRenderTile(t *testing.T) {
+	compositor := Compositor{
+		width:      640,
+		height:     480,
+		padding:    0,
+		scale:      1.0,
+		background: fills.NewSolidFill(black),
+		grid:       grids.NewSquareGrid(green, 64, grids.Approximate, false),
+
+		renderer: lines.Lines{
+			Palette:   palettes.Fire,
+			AntiAlias: kernels.Vertical,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	tiles, err := Tiles(len(samples), audio.SampleRate, 256, 512)
+	if err != nil {
+		t.Fatalf("error creating tiles (%v)", err)
+	}
+
+	for _, tile := range tiles {
+		if img, err := compositor.RenderTile(samples, tile); err != nil {
+			t.Fatalf("error rendering tile %v (%v)", tile.Index, err)
+		} else if img.Bounds().Dx() != tile.Width || img.Bounds().Dy() != 480 {
+			t.Errorf("incorrect tile %v size - expected:%vx%v, got:%v", tile.Index, tile.Width, 480, img.Bounds())
+		}
+	}
+}
+
+func TestRenderTileGrid(t *testing.T) {
+	compositor := Compositor{
+		width:      640,
+		height:     480,
+		padding:    10,
+		scale:      1.0,
+		background: fills.NewSolidFill(black),
+		grid:       grids.NewSquareGrid(green, 64, grids.Approximate, true),
+
+		renderer: lines.Lines{
+			Palette:   palettes.Fire,
+			AntiAlias: kernels.Vertical,
+		},
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)
+
+	tiles, err := Tiles(len(samples), audio.SampleRate, 256, 500)
+	if err != nil {
+		t.Fatalf("error creating tiles (%v)", err)
+	}
+
+	// ... vertical lines should be continuous across tiles, without a border at the tile edges
+	for _, tile := range tiles {
+		img, err := compositor.RenderTile(samples, tile)
+		if err != nil {
+			t.Fatalf("error rendering tile %v (%v)", tile.Index, err)
+		}
+
+		for x := 0; x < tile.Width; x++ {
+			expected := (tile.X+x)%64 == 0 && tile.X+x > 0
+			if drawn := img.NRGBAAt(x, 0) == green; drawn != expected {
+				t.Errorf("incorrect tile %v grid at x:%v - expected line:%v, got:%v", tile.Index, tile.X+x, expected, drawn)
+			}
+		}
+	}
+}
@@ -10,7 +10,9 @@ type FillSpec interface {
 }
 
 func Fill(img *image.NRGBA, spec FillSpec) {
-	spec.Fill(img)
+	if spec != nil {
+		spec.Fill(img)
+	}
 }
 
 type SolidFill struct {
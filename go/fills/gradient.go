@@ -0,0 +1,76 @@
+package fills
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+// LinearGradientFill fills the image with a linear gradient through the colours (spaced evenly
+// along the gradient line). The angle is in degrees, clockwise from 'to top' as for a CSS
+// linear-gradient i.e. 90° is left to right and 180° is top to bottom.
+type LinearGradientFill struct {
+	colours []color.NRGBA
+	angle   float64
+}
+
+// RadialGradientFill fills the image with an elliptical gradient through the colours, from the
+// centre of the image to the corners.
+type RadialGradientFill struct {
+	colours []color.NRGBA
+}
+
+func NewLinearGradientFill(colours []color.NRGBA, angle float64) LinearGradientFill {
+	return LinearGradientFill{
+		colours: append([]color.NRGBA{}, colours...),
+		angle:   angle,
+	}
+}
+
+func NewRadialGradientFill(colours []color.NRGBA) RadialGradientFill {
+	return RadialGradientFill{
+		colours: append([]color.NRGBA{}, colours...),
+	}
+}
+
+func (f LinearGradientFill) Fill(img *image.NRGBA) {
+	bounds := img.Bounds()
+	w := float64(bounds.Dx())
+	h := float64(bounds.Dy())
+
+	θ := f.angle * math.Pi / 180
+	dx := math.Sin(θ)
+	dy := -math.Cos(θ)
+	L := math.Abs(w*dx) + math.Abs(h*dy)
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			u := float64(x-bounds.Min.X) + 0.5 - w/2
+			v := float64(y-bounds.Min.Y) + 0.5 - h/2
+			t := 0.5
+			if L > 0 {
+				t += (u*dx + v*dy) / L
+			}
+
+			img.SetNRGBA(x, y, palettes.Interpolate(f.colours, t))
+		}
+	}
+}
+
+func (f RadialGradientFill) Fill(img *image.NRGBA) {
+	bounds := img.Bounds()
+	w := float64(bounds.Dx())
+	h := float64(bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			u := (float64(x-bounds.Min.X) + 0.5 - w/2) / (w / 2)
+			v := (float64(y-bounds.Min.Y) + 0.5 - h/2) / (h / 2)
+			t := math.Hypot(u, v) / math.Sqrt2
+
+			img.SetNRGBA(x, y, palettes.Interpolate(f.colours, t))
+		}
+	}
+}
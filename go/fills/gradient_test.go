@@ -0,0 +1,58 @@
+package fills
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestLinearGradientFill(t *testing.T) {
+	black := color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	tests := []struct {
+		angle    float64
+		expected map[image.Point]color.NRGBA
+	}{
+		{180, map[image.Point]color.NRGBA{{0, 0}: {R: 0x10, G: 0x10, B: 0x10, A: 0xff}, {3, 7}: {R: 0xef, G: 0xef, B: 0xef, A: 0xff}}},
+		{0, map[image.Point]color.NRGBA{{0, 0}: {R: 0xef, G: 0xef, B: 0xef, A: 0xff}, {3, 7}: {R: 0x10, G: 0x10, B: 0x10, A: 0xff}}},
+		{90, map[image.Point]color.NRGBA{{0, 5}: {R: 0x20, G: 0x20, B: 0x20, A: 0xff}, {3, 5}: {R: 0xdf, G: 0xdf, B: 0xdf, A: 0xff}}},
+	}
+
+	for _, test := range tests {
+		img := image.NewNRGBA(image.Rect(0, 0, 4, 8))
+
+		NewLinearGradientFill([]color.NRGBA{black, white}, test.angle).Fill(img)
+
+		for p, expected := range test.expected {
+			if c := img.NRGBAAt(p.X, p.Y); c != expected {
+				t.Errorf("angle %v: incorrect colour at %v - expected:%v, got:%v", test.angle, p, expected, c)
+			}
+		}
+	}
+}
+
+func TestRadialGradientFill(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0x00}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+
+	NewRadialGradientFill([]color.NRGBA{red, blue}).Fill(img)
+
+	centre := img.NRGBAAt(32, 16)
+	corner := img.NRGBAAt(0, 0)
+
+	if centre.R < 0xf0 || centre.A < 0xf0 {
+		t.Errorf("incorrect centre colour - expected:~%v, got:%v", red, centre)
+	}
+
+	if corner.B < 0xf0 || corner.A > 0x10 {
+		t.Errorf("incorrect corner colour - expected:~%v, got:%v", blue, corner)
+	}
+
+	// ... elliptical i.e. the same colour at the left edge and the top edge
+	if p, q := img.NRGBAAt(0, 16), img.NRGBAAt(32, 0); p.A < q.A-4 || p.A > q.A+4 {
+		t.Errorf("expected elliptical gradient - got %v and %v", p, q)
+	}
+}
@@ -0,0 +1,97 @@
+package fills
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// ImageMode determines how an ImageFill image is fitted to the filled image:
+//   - Stretch scales the image to the size of the filled image
+//   - Tile repeats the image at its original size
+//   - Cover scales the image (preserving the aspect ratio) to cover the filled image, cropping
+//     the overflow equally on either side
+type ImageMode int
+
+const (
+	Cover ImageMode = iota
+	Stretch
+	Tile
+)
+
+func ParseImageMode(s string) (ImageMode, error) {
+	switch strings.ToLower(s) {
+	case "cover", "":
+		return Cover, nil
+	case "stretch":
+		return Stretch, nil
+	case "tile":
+		return Tile, nil
+	}
+
+	return Cover, fmt.Errorf("invalid image fill mode (%v)", s)
+}
+
+func (m ImageMode) String() string {
+	return [...]string{"cover", "stretch", "tile"}[m]
+}
+
+// ImageFill fills the image with an image or texture.
+type ImageFill struct {
+	image image.Image
+	mode  ImageMode
+}
+
+func NewImageFill(img image.Image, mode ImageMode) ImageFill {
+	return ImageFill{
+		image: img,
+		mode:  mode,
+	}
+}
+
+func (f ImageFill) Fill(img *image.NRGBA) {
+	bounds := img.Bounds()
+
+	if f.image == nil || f.image.Bounds().Empty() || bounds.Empty() {
+		draw.Draw(img, bounds, image.Transparent, image.Point{}, draw.Src)
+		return
+	}
+
+	src := f.image.Bounds()
+
+	switch f.mode {
+	case Stretch:
+		draw.CatmullRom.Scale(img, bounds, f.image, src, draw.Src, nil)
+
+	case Tile:
+		for y := bounds.Min.Y; y < bounds.Max.Y; y += src.Dy() {
+			for x := bounds.Min.X; x < bounds.Max.X; x += src.Dx() {
+				r := image.Rect(x, y, x+src.Dx(), y+src.Dy()).Intersect(bounds)
+
+				draw.Draw(img, r, f.image, src.Min, draw.Src)
+			}
+		}
+
+	default:
+		w := float64(bounds.Dx())
+		h := float64(bounds.Dy())
+		sw := float64(src.Dx())
+		sh := float64(src.Dy())
+
+		// ... crop the source image to the aspect ratio of the filled image
+		crop := src
+		if sw/sh > w/h {
+			cw := int(sh * w / h)
+			crop.Min.X = src.Min.X + (src.Dx()-cw)/2
+			crop.Max.X = crop.Min.X + cw
+		} else {
+			ch := int(sw * h / w)
+			crop.Min.Y = src.Min.Y + (src.Dy()-ch)/2
+			crop.Max.Y = crop.Min.Y + ch
+		}
+
+		draw.CatmullRom.Scale(img, bounds, f.image, crop, draw.Src, nil)
+	}
+}
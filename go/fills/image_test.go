@@ -0,0 +1,66 @@
+package fills
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestImageFill(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+
+	// ... 4x2 texture (scaled textures are interpolated so only the edges are checked), left half red and right half blue
+	texture := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 4; x++ {
+			if x < 2 {
+				texture.SetNRGBA(x, y, red)
+			} else {
+				texture.SetNRGBA(x, y, blue)
+			}
+		}
+	}
+
+	tests := []struct {
+		mode     ImageMode
+		expected map[int]color.NRGBA
+	}{
+		{Stretch, map[int]color.NRGBA{0: red, 1: red, 6: blue, 7: blue}},
+		{Tile, map[int]color.NRGBA{0: red, 1: red, 2: blue, 3: blue, 4: red, 5: red, 6: blue, 7: blue}},
+		{Cover, map[int]color.NRGBA{0: red, 1: red, 6: blue, 7: blue}},
+	}
+
+	for _, test := range tests {
+		img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+
+		NewImageFill(texture, test.mode).Fill(img)
+
+		for x, expected := range test.expected {
+			if c := img.NRGBAAt(x, 7); c != expected {
+				t.Errorf("%v: incorrect colour at %v - expected:%v, got:%v", test.mode, x, expected, c)
+			}
+		}
+	}
+}
+
+func TestParseImageMode(t *testing.T) {
+	tests := map[string]ImageMode{
+		"":        Cover,
+		"cover":   Cover,
+		"STRETCH": Stretch,
+		"tile":    Tile,
+	}
+
+	for s, expected := range tests {
+		if mode, err := ParseImageMode(s); err != nil {
+			t.Errorf("error parsing image mode %q (%v)", s, err)
+		} else if mode != expected {
+			t.Errorf("incorrect image mode for %q - expected:%v, got:%v", s, expected, mode)
+		}
+	}
+
+	if _, err := ParseImageMode("fit"); err == nil {
+		t.Errorf("expected error parsing invalid image mode")
+	}
+}
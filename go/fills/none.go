@@ -0,0 +1,16 @@
+package fills
+
+import (
+	"image"
+)
+
+// NoFill leaves the background transparent e.g. for waveforms that are overlaid on video or web pages.
+type NoFill struct {
+}
+
+func NewNoFill() FillSpec {
+	return NoFill{}
+}
+
+func (f NoFill) Fill(img *image.NRGBA) {
+}
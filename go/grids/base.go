@@ -0,0 +1,105 @@
+package grids
+
+import (
+	"image/color"
+	"math"
+	"time"
+)
+
+// gridBase holds the settings common to all the grids, i.e. the grid colour, overlay, baseline,
+// line style and elements along with the time range, vertical scale and (for tiles) timeline origin
+// of the rendered waveform.
+// The With... functions update the settings of any Configurable grid through an Option.
+type gridBase struct {
+	colour   color.NRGBA
+	overlay  bool
+	baseline Baseline
+	style    *GridStyle
+	elements Elements
+	start    time.Duration
+	end      time.Duration
+	vscale   float64
+	origin   *int
+}
+
+// Option updates one of the settings common to all the grids in this package.
+type Option func(*gridBase)
+
+// Configurable is implemented by grids that accept the common grid settings. The With... functions
+// return any other GridSpec unchanged.
+type Configurable interface {
+	With(options ...Option) GridSpec
+}
+
+func with(spec GridSpec, option Option) GridSpec {
+	if g, ok := spec.(Configurable); ok {
+		return g.With(option)
+	}
+
+	return spec
+}
+
+func newGridBase(colour color.NRGBA, overlay bool) gridBase {
+	return gridBase{
+		colour:  colour,
+		overlay: overlay,
+		vscale:  1.0,
+	}
+}
+
+// WithOrigin returns a copy of the grid for an image (e.g. a tile) positioned at x on a longer
+// timeline. The vertical lines are laid out at the nominal grid spacing from the start of the
+// timeline so that they are continuous across adjacent images.
+func WithOrigin(spec GridSpec, x int) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.origin = &x
+	})
+}
+
+func (b gridBase) Colour() color.NRGBA {
+	return b.colour
+}
+
+func (b gridBase) Overlay() bool {
+	return b.overlay
+}
+
+func (b gridBase) Style() GridStyle {
+	return defaultStyle(b.style, b.colour)
+}
+
+func (b gridBase) Elements() Elements {
+	return b.elements
+}
+
+// tiled lays out vertical lines at intervals of dw from the start of the timeline for an image
+// spanning x0 to x1 (inclusive) positioned at 'origin' on the timeline.
+func tiled(x0, x1, origin int, dw float64) []int {
+	vlines := []int{}
+
+	if dw > 0 {
+		for line := max(1, int(math.Floor(float64(origin)/dw))); ; line++ {
+			gx := int(math.Round(float64(line)*dw)) - origin + x0
+			if gx > x1 {
+				break
+			} else if gx >= x0 {
+				vlines = append(vlines, gx)
+			}
+		}
+	}
+
+	return vlines
+}
+
+// spacing returns the nominal spacing of grid lines of the given size and fit.
+func spacing(size uint, fit Fit) float64 {
+	switch fit {
+	case LargerThan:
+		return float64(size + 1)
+
+	case SmallerThan:
+		return float64(size) - 1
+	}
+
+	return float64(size)
+}
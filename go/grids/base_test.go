@@ -0,0 +1,75 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWithSettings(t *testing.T) {
+	green := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	style := GridStyle{Interval: 4}
+	elements := Elements{Border: Hidden}
+
+	specs := []GridSpec{
+		NewNoGrid(),
+		NewSquareGrid(green, 64, Approximate, false),
+		NewRectangularGrid(green, 64, 48, Approximate, false),
+		NewTimelineGrid(green, 64, Approximate, Amplitude, true, false),
+		NewDBGrid(green, 64, Approximate, true, false),
+	}
+
+	for _, spec := range specs {
+		spec = WithBaseline(spec, Bottom)
+		spec = WithStyle(spec, style)
+		spec = WithElements(spec, elements)
+		spec = WithTimeRange(spec, 1*time.Second, 2*time.Second)
+		spec = WithScale(spec, 0.5)
+
+		expected := gridBase{
+			colour:   spec.Colour(),
+			baseline: Bottom,
+			style:    &style,
+			elements: elements,
+			start:    1 * time.Second,
+			end:      2 * time.Second,
+			vscale:   0.5,
+		}
+
+		spec.(Configurable).With(func(b *gridBase) {
+			if b.baseline != expected.baseline || b.elements != expected.elements || b.start != expected.start || b.end != expected.end || b.vscale != expected.vscale {
+				t.Errorf("incorrect %T settings\n   expected:%+v\n   got:     %+v", spec, expected, *b)
+			}
+
+			if b.style == nil || b.style.Interval != style.Interval {
+				t.Errorf("incorrect %T style - expected:%+v, got:%+v", spec, style, b.style)
+			}
+		})
+	}
+}
+
+func TestWithOrigin(t *testing.T) {
+	green := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	bounds := image.Rect(0, 0, 100, 100)
+
+	tests := []struct {
+		spec     GridSpec
+		origin   int
+		expected []int
+	}{
+		{NewSquareGrid(green, 64, Approximate, false), 0, []int{64}},
+		{NewSquareGrid(green, 64, Approximate, false), 100, []int{28, 92}},
+		{NewSquareGrid(green, 64, Approximate, false), 128, []int{0, 64}},
+		{WithTimeRange(NewTimelineGrid(green, 64, Approximate, NoAxis, false, false), 0, 1*time.Second), 0, []int{50}},
+		{WithTimeRange(NewTimelineGrid(green, 64, Approximate, NoAxis, false, false), 1*time.Second, 2*time.Second), 100, []int{0, 50}},
+	}
+
+	for _, test := range tests {
+		spec := WithOrigin(test.spec, test.origin)
+		if vlines := spec.VLines(bounds, 0); !reflect.DeepEqual(vlines, test.expected) {
+			t.Errorf("incorrect %T vertical lines at origin %v\n   expected:%v\n   got:     %v", test.spec, test.origin, test.expected, vlines)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package grids
+
+import (
+	"math"
+)
+
+// Baseline is the position of the waveform baseline from which the horizontal grid lines are
+// laid out.
+type Baseline int
+
+const (
+	Centre Baseline = iota
+	Bottom
+	Top
+)
+
+func (b Baseline) String() string {
+	return [...]string{"centre", "bottom", "top"}[b]
+}
+
+// WithBaseline returns a copy of the grid with the horizontal lines laid out from the baseline.
+func WithBaseline(spec GridSpec, baseline Baseline) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.baseline = baseline
+	})
+}
+
+// baselines lays out horizontal grid lines at intervals of dw from a top or bottom baseline.
+func baselines(y0, y1 int, dw float64, baseline Baseline) []int {
+	hlines := []int{}
+
+	if dw > 0 {
+		for line := 1; ; line++ {
+			gy := math.Round(float64(y1) - float64(line)*dw)
+			if baseline == Top {
+				gy = math.Round(float64(y0) + float64(line)*dw)
+			}
+
+			if gy > float64(y0) && gy < float64(y1) {
+				hlines = append(hlines, int(gy))
+				continue
+			}
+
+			break
+		}
+	}
+
+	return hlines
+}
@@ -0,0 +1,63 @@
+package grids_test
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/grids"
+)
+
+// custom is a GridSpec implemented outside the grids package.
+type custom struct{}
+
+func (g custom) Colour() color.NRGBA {
+	return color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+}
+
+func (g custom) Overlay() bool {
+	return false
+}
+
+func (g custom) Border(bounds image.Rectangle, padding int) *image.Rectangle {
+	return nil
+}
+
+func (g custom) VLines(bounds image.Rectangle, padding int) []int {
+	return []int{10}
+}
+
+func (g custom) HLines(bounds image.Rectangle, padding int) []int {
+	return []int{}
+}
+
+func (g custom) Centre(bounds image.Rectangle, padding int) *int {
+	return nil
+}
+
+func (g custom) Style() grids.GridStyle {
+	return grids.GridStyle{Minor: grids.LineStyle{Colour: g.Colour(), Width: 1}}
+}
+
+func (g custom) Elements() grids.Elements {
+	return grids.Elements{}
+}
+
+func TestCustomGridSpec(t *testing.T) {
+	var spec grids.GridSpec = custom{}
+
+	spec = grids.WithBaseline(spec, grids.Bottom)
+	spec = grids.WithElements(spec, grids.Elements{Border: grids.Hidden})
+	spec = grids.WithTimeRange(spec, 0, 1*time.Second)
+	spec = grids.WithOrigin(spec, 64)
+
+	if !reflect.DeepEqual(spec, grids.GridSpec(custom{})) {
+		t.Errorf("incorrectly configured custom grid - expected:%v, got:%v", custom{}, spec)
+	}
+
+	if img := grids.Grid(spec, 32, 32, 0); img.NRGBAAt(10, 16) != spec.Colour() {
+		t.Errorf("incorrectly rendered custom grid - expected:%v, got:%v", spec.Colour(), img.NRGBAAt(10, 16))
+	}
+}
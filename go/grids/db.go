@@ -0,0 +1,77 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+)
+
+// DBGrid lays out the horizontal grid lines at -6dB intervals (relative to full scale) for the
+// height, padding and vertical scaling of the rendered waveform, with optional dB labels. The
+// vertical grid lines (if any) are spaced 'size' pixels apart according to 'fit', as for a SquareGrid.
+type DBGrid struct {
+	gridBase
+	size   uint
+	fit    Fit
+	labels bool
+}
+
+func NewDBGrid(colour color.NRGBA, size uint, fit Fit, labels bool, overlay bool) DBGrid {
+	return DBGrid{
+		gridBase: newGridBase(colour, overlay),
+		size:     size,
+		fit:      fit,
+		labels:   labels,
+	}
+}
+
+func (g DBGrid) With(options ...Option) GridSpec {
+	for _, option := range options {
+		option(&g.gridBase)
+	}
+
+	return g
+}
+
+func (g DBGrid) Centre(bounds image.Rectangle, padding int) *int {
+	return centre(*g.Border(bounds, padding), g.baseline)
+}
+
+func (g DBGrid) Border(bounds image.Rectangle, padding int) *image.Rectangle {
+	border := image.Rect(bounds.Min.X+padding, bounds.Min.Y+padding, bounds.Max.X-1-padding, bounds.Max.Y-1-padding)
+
+	return &border
+}
+
+func (g DBGrid) VLines(bounds image.Rectangle, padding int) []int {
+	if g.size == 0 {
+		return []int{}
+	}
+
+	return SquareGrid{gridBase: g.gridBase, size: g.size, fit: g.fit}.VLines(bounds, padding)
+}
+
+func (g DBGrid) HLines(bounds image.Rectangle, padding int) []int {
+	hlines := []int{}
+	border := g.Border(bounds, padding)
+
+	for _, tick := range decibels(border.Min.Y, border.Max.Y, g.vscale, g.baseline) {
+		hlines = append(hlines, tick.at)
+	}
+
+	return hlines
+}
+
+// Labels returns the dB labels for the horizontal lines, if the grid is labelled.
+func (g DBGrid) Labels(bounds image.Rectangle, padding int) []Label {
+	labels := []Label{}
+
+	if g.labels {
+		border := g.Border(bounds, padding)
+
+		for _, tick := range decibels(border.Min.Y, border.Max.Y, g.vscale, g.baseline) {
+			labels = append(labels, Label{Text: tick.label, X: border.Min.X + 3, Y: tick.at - 2})
+		}
+	}
+
+	return labels
+}
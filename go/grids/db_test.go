@@ -0,0 +1,105 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestDBGridHLines(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{96, 289, 144, 241, 168, 217}
+	var gridspec GridSpec = NewDBGrid(color.NRGBA{}, 64, Approximate, false, false)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestDBGridHLinesWithPadding(t *testing.T) {
+	bounds := image.Rect(0, 0, 643, 388)
+	padding := 1
+	expected := []int{97, 290, 145, 242, 169, 218}
+	var gridspec GridSpec = NewDBGrid(color.NRGBA{}, 64, Approximate, false, false)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestDBGridHLinesWithBaseline(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{192, 288, 337, 361}
+	gridspec := WithBaseline(NewDBGrid(color.NRGBA{}, 64, Approximate, false, false), Bottom)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestDBGridHLinesWithScale(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{95, 290, 144, 241, 168, 217}
+	gridspec := WithScale(NewDBGrid(color.NRGBA{}, 64, Approximate, false, false), 2.0)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestDBGridVLines(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := SquareGrid{size: 64}.VLines(bounds, padding)
+
+	if vlines := NewDBGrid(color.NRGBA{}, 64, Approximate, false, false).VLines(bounds, padding); !reflect.DeepEqual(vlines, expected) {
+		t.Errorf("Incorrect vertical lines:\n   expected:%v\n   got:     %v", expected, vlines)
+	}
+
+	if vlines := NewDBGrid(color.NRGBA{}, 0, Approximate, false, false).VLines(bounds, padding); len(vlines) != 0 {
+		t.Errorf("Unexpected vertical lines %v", vlines)
+	}
+}
+
+func TestDBGridVLinesWithFit(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+
+	for _, fit := range []Fit{Approximate, Exact, AtLeast, AtMost, LargerThan, SmallerThan} {
+		expected := SquareGrid{size: 100, fit: fit}.VLines(bounds, padding)
+
+		if vlines := NewDBGrid(color.NRGBA{}, 100, fit, false, false).VLines(bounds, padding); !reflect.DeepEqual(vlines, expected) {
+			t.Errorf("Incorrect vertical lines for fit %v:\n   expected:%v\n   got:     %v", fit, expected, vlines)
+		}
+	}
+}
+
+func TestDBGridLabels(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []Label{
+		{Text: "-6dB", X: 3, Y: 94},
+		{Text: "-6dB", X: 3, Y: 287},
+		{Text: "-12dB", X: 3, Y: 142},
+		{Text: "-12dB", X: 3, Y: 239},
+		{Text: "-18dB", X: 3, Y: 166},
+		{Text: "-18dB", X: 3, Y: 215},
+	}
+
+	labels := NewDBGrid(color.NRGBA{}, 64, Approximate, true, false).Labels(bounds, padding)
+	if !reflect.DeepEqual(labels, expected) {
+		t.Errorf("Incorrect labels:\n   expected:%v\n   got:     %v", expected, labels)
+	}
+
+	if labels := NewDBGrid(color.NRGBA{}, 64, Approximate, false, false).Labels(bounds, padding); len(labels) != 0 {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+}
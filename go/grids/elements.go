@@ -0,0 +1,81 @@
+package grids
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layer determines whether a grid element is hidden or drawn under or over the waveform. The
+// default layer follows the grid Overlay setting.
+type Layer int
+
+const (
+	DefaultLayer Layer = iota
+	Hidden
+	Under
+	Over
+)
+
+func ParseLayer(s string) (Layer, error) {
+	switch strings.ToLower(s) {
+	case "", "default":
+		return DefaultLayer, nil
+	case "none", "hidden":
+		return Hidden, nil
+	case "under":
+		return Under, nil
+	case "over", "overlay":
+		return Over, nil
+	}
+
+	return DefaultLayer, fmt.Errorf("invalid grid layer (%v)", s)
+}
+
+func (l Layer) String() string {
+	return [...]string{"default", "none", "under", "over"}[l]
+}
+
+// Elements independently enables the grid border, vertical lines, horizontal lines and centre
+// (zero amplitude) line and determines whether each is drawn under or over the waveform. The
+// centre line is hidden by default unless the grid style includes a centre line style.
+type Elements struct {
+	Border Layer
+	VLines Layer
+	HLines Layer
+	Centre Layer
+}
+
+// WithElements returns a copy of the grid with the grid elements.
+func WithElements(spec GridSpec, elements Elements) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.elements = elements
+	})
+}
+
+// resolve replaces the default layers with the layer implied by the grid overlay and centre line style.
+func (e Elements) resolve(overlay bool, style GridStyle) Elements {
+	layer := Under
+	if overlay {
+		layer = Over
+	}
+
+	f := func(l Layer) Layer {
+		if l == DefaultLayer {
+			return layer
+		}
+
+		return l
+	}
+
+	centre := e.Centre
+	if centre == DefaultLayer && style.Centre == nil {
+		centre = Hidden
+	}
+
+	return Elements{
+		Border: f(e.Border),
+		VLines: f(e.VLines),
+		HLines: f(e.HLines),
+		Centre: f(centre),
+	}
+}
@@ -0,0 +1,114 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseLayer(t *testing.T) {
+	tests := map[string]Layer{
+		"":        DefaultLayer,
+		"default": DefaultLayer,
+		"none":    Hidden,
+		"hidden":  Hidden,
+		"under":   Under,
+		"OVER":    Over,
+		"overlay": Over,
+	}
+
+	for s, expected := range tests {
+		if layer, err := ParseLayer(s); err != nil {
+			t.Errorf("error parsing layer %q (%v)", s, err)
+		} else if layer != expected {
+			t.Errorf("incorrectly parsed layer %q - expected:%v, got:%v", s, expected, layer)
+		}
+	}
+
+	if _, err := ParseLayer("sideways"); err == nil {
+		t.Errorf("expected error parsing invalid layer")
+	}
+}
+
+func TestElementsResolve(t *testing.T) {
+	centre := LineStyle{Width: 1}
+
+	tests := []struct {
+		elements Elements
+		overlay  bool
+		style    GridStyle
+		expected Elements
+	}{
+		{Elements{}, false, GridStyle{}, Elements{Border: Under, VLines: Under, HLines: Under, Centre: Hidden}},
+		{Elements{}, true, GridStyle{}, Elements{Border: Over, VLines: Over, HLines: Over, Centre: Hidden}},
+		{Elements{}, true, GridStyle{Centre: &centre}, Elements{Border: Over, VLines: Over, HLines: Over, Centre: Over}},
+		{Elements{Border: Over, VLines: Hidden, Centre: Under}, false, GridStyle{}, Elements{Border: Over, VLines: Hidden, HLines: Under, Centre: Under}},
+	}
+
+	for _, test := range tests {
+		if elements := test.elements.resolve(test.overlay, test.style); elements != test.expected {
+			t.Errorf("incorrectly resolved elements %+v\n   expected:%+v\n   got:     %+v", test.elements, test.expected, elements)
+		}
+	}
+}
+
+func TestLayers(t *testing.T) {
+	green := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	spec := WithElements(NewSquareGrid(green, 32, Exact, false), Elements{
+		Border: Over,
+		VLines: Hidden,
+		Centre: Over,
+	})
+
+	under, over := Layers(spec, 128, 128, 0, 1)
+
+	tests := []struct {
+		layer    string
+		x, y     int
+		expected bool
+	}{
+		{"over", 0, 10, true},
+		{"over", 127, 10, true},
+		{"under", 0, 10, false},
+		{"over", 32, 10, false},
+		{"under", 32, 10, false},
+		{"under", 10, 31, true},
+		{"over", 10, 31, false},
+		{"over", 10, 63, true},
+	}
+
+	for _, test := range tests {
+		img := under
+		if test.layer == "over" {
+			img = over
+		}
+
+		if drawn := img.NRGBAAt(test.x, test.y) == green; drawn != test.expected {
+			t.Errorf("incorrect %v layer pixel at (%v,%v) - expected drawn:%v, got:%v", test.layer, test.x, test.y, test.expected, drawn)
+		}
+	}
+}
+
+func TestSupersampledLayers(t *testing.T) {
+	green := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	spec := WithStyle(NewDBGrid(green, 32, Approximate, true, true), GridStyle{
+		Minor:    LineStyle{Colour: green, Width: 1, Dash: []uint{2, 1}},
+		Major:    LineStyle{Colour: green, Width: 3},
+		Interval: 2,
+	})
+
+	_, reference := Layers(spec, 128, 96, 4, 1)
+	_, over := Layers(spec, 128, 96, 4, 3)
+
+	if over.Bounds() != image.Rect(0, 0, 384, 288) {
+		t.Fatalf("incorrect supersampled layer size - expected:%v, got:%v", image.Rect(0, 0, 384, 288), over.Bounds())
+	}
+
+	for y := 0; y < over.Bounds().Dy(); y++ {
+		for x := 0; x < over.Bounds().Dx(); x++ {
+			if expected, c := reference.NRGBAAt(x/3, y/3), over.NRGBAAt(x, y); c != expected {
+				t.Fatalf("incorrect supersampled grid at (%v,%v) - expected:%v, got:%v", x, y, expected, c)
+			}
+		}
+	}
+}
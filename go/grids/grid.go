@@ -3,6 +3,11 @@ package grids
 import (
 	"image"
 	"image/color"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
 )
 
 type GridSpec interface {
@@ -11,6 +16,24 @@ type GridSpec interface {
 	Border(bounds image.Rectangle, padding int) *image.Rectangle
 	VLines(bounds image.Rectangle, padding int) []int
 	HLines(bounds image.Rectangle, padding int) []int
+	Centre(bounds image.Rectangle, padding int) *int
+	Style() GridStyle
+	Elements() Elements
+}
+
+// Labelled is implemented by grids with axis labels.
+type Labelled interface {
+	Labels(bounds image.Rectangle, padding int) []Label
+}
+
+// Label is a grid axis label, drawn with the left end of the text baseline at (X,Y). Vertical labels
+// label the vertical grid lines and are drawn (or hidden) with the vertical lines, otherwise labels
+// are drawn (or hidden) with the horizontal lines.
+type Label struct {
+	Text     string
+	X        int
+	Y        int
+	Vertical bool
 }
 
 type Fit int
@@ -28,10 +51,39 @@ func (f Fit) String() string {
 	return [...]string{"~", "=", "≥", "≤", ">", "<"}[f]
 }
 
+// Grid renders all the visible grid elements as a single image.
 func Grid(spec GridSpec, width, height, padding int) *image.NRGBA {
+	return render(spec, width, height, padding, 1, func(layer Layer) bool {
+		return layer != Hidden
+	})
+}
+
+// Layers renders the grid elements drawn under the waveform and the grid elements drawn over the
+// waveform as separate images. For N > 1 the layers are rendered at N times the image size (for
+// compositing with a supersampled waveform) with every line and label scaled by N, so that the
+// layers downsample to the same grid as for N = 1.
+func Layers(spec GridSpec, width, height, padding int, N int) (under *image.NRGBA, over *image.NRGBA) {
+	N = max(1, N)
+
+	under = render(spec, width, height, padding, N, func(layer Layer) bool {
+		return layer == Under
+	})
+
+	over = render(spec, width, height, padding, N, func(layer Layer) bool {
+		return layer == Over
+	})
+
+	return
+}
+
+// render draws the grid elements for which the 'visible' function returns true for the element layer,
+// scaled by N.
+func render(spec GridSpec, width, height, padding int, N int, visible func(Layer) bool) *image.NRGBA {
 	bounds := image.Rect(0, 0, width, height)
-	img := image.NewNRGBA(bounds)
+	img := image.NewNRGBA(image.Rect(0, 0, N*width, N*height))
 	colour := spec.Colour()
+	style := spec.Style()
+	elements := spec.Elements().resolve(spec.Overlay(), style)
 
 	// calculate grid metrics
 	x0 := bounds.Min.X
@@ -49,35 +101,99 @@ func Grid(spec GridSpec, width, height, padding int) *image.NRGBA {
 		y1 = border.Max.Y
 	}
 
-	// vertical lines
+	centre := spec.Centre(bounds, padding)
+	origin := y1
+	if centre != nil {
+		origin = *centre
+	}
+
+	// vertical and horizontal lines (minor lines first so that major lines are drawn over them)
 	vlines := spec.VLines(bounds, padding)
-	for _, x := range vlines {
-		for y := y0; y <= y1; y++ {
-			img.Set(x, y, colour)
+	hlines := spec.HLines(bounds, padding)
+	vmajor := majors(vlines, x0, style.Interval)
+	hmajor := majors(hlines, origin, style.Interval)
+
+	for _, major := range []bool{false, true} {
+		line := style.Minor
+		if major {
+			line = style.Major
+		}
+
+		for i, x := range vlines {
+			if vmajor[i] == major && visible(elements.VLines) {
+				vline(img, x, y0, y1, line, N)
+			}
+		}
+
+		for i, y := range hlines {
+			if hmajor[i] == major && visible(elements.HLines) {
+				hline(img, y, x0, x1, line, N)
+			}
 		}
 	}
 
-	// horizontal lines
-	// c := color.NRGBA{R: 0xff, G: 0x80, B: 0x00, A: 0xff}
-	hlines := spec.HLines(bounds, padding)
-	for _, y := range hlines {
-		for x := x0; x <= x1; x++ {
-			img.Set(x, y, colour)
+	// centre line
+	if centre != nil && visible(elements.Centre) {
+		if style.Centre != nil {
+			hline(img, *centre, x0, x1, *style.Centre, N)
+		} else {
+			hline(img, *centre, x0, x1, style.Major, N)
 		}
 	}
 
 	// border
-	if border != nil {
-		for x := border.Min.X; x <= border.Max.X; x++ {
-			img.Set(x, border.Min.Y, colour)
-			img.Set(x, border.Max.Y, colour)
+	if border != nil && visible(elements.Border) {
+		hline(img, border.Min.Y, border.Min.X, border.Max.X, style.Major, N)
+		hline(img, border.Max.Y, border.Min.X, border.Max.X, style.Major, N)
+		vline(img, border.Min.X, border.Min.Y, border.Max.Y, style.Major, N)
+		vline(img, border.Max.X, border.Min.Y, border.Max.Y, style.Major, N)
+	}
+
+	// labels
+	if labelled, ok := spec.(Labelled); ok {
+		drawer := font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(colour),
+			Face: basicfont.Face7x13,
 		}
 
-		for y := border.Min.Y; y <= border.Max.Y; y++ {
-			img.Set(border.Min.X, y, colour)
-			img.Set(border.Max.X, y, colour)
+		for _, label := range labelled.Labels(bounds, padding) {
+			layer := elements.HLines
+			if label.Vertical {
+				layer = elements.VLines
+			}
+
+			if w := drawer.MeasureString(label.Text).Ceil(); label.X+w < x1 && visible(layer) {
+				if N > 1 {
+					text(img, label, w, colour, N)
+				} else {
+					drawer.Dot = fixed.P(label.X, label.Y)
+					drawer.DrawString(label.Text)
+				}
+			}
 		}
 	}
 
 	return img
 }
+
+// text draws a label at N times the font size by drawing the label into a 1x image and scaling it up.
+func text(img *image.NRGBA, label Label, width int, colour color.NRGBA, N int) {
+	face := basicfont.Face7x13
+	ascent := face.Metrics().Ascent.Ceil()
+	descent := face.Metrics().Descent.Ceil()
+
+	src := image.NewNRGBA(image.Rect(0, 0, width, ascent+descent))
+	drawer := font.Drawer{
+		Dst:  src,
+		Src:  image.NewUniform(colour),
+		Face: face,
+		Dot:  fixed.P(0, ascent),
+	}
+
+	drawer.DrawString(label.Text)
+
+	x := N * label.X
+	y := N * (label.Y - ascent)
+	draw.NearestNeighbor.Scale(img, image.Rect(x, y, x+N*width, y+N*(ascent+descent)), src, src.Bounds(), draw.Over, nil)
+}
@@ -2,22 +2,26 @@ package grids
 
 import (
 	"image"
-	"image/color"
 )
 
 type NoGrid struct {
+	gridBase
 }
 
 func NewNoGrid() GridSpec {
 	return NoGrid{}
 }
 
-func (g NoGrid) Colour() color.NRGBA {
-	return color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x00}
+func (g NoGrid) With(options ...Option) GridSpec {
+	for _, option := range options {
+		option(&g.gridBase)
+	}
+
+	return g
 }
 
-func (g NoGrid) Overlay() bool {
-	return false
+func (g NoGrid) Centre(bounds image.Rectangle, padding int) *int {
+	return nil
 }
 
 func (g NoGrid) Border(bounds image.Rectangle, padding int) *image.Rectangle {
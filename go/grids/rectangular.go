@@ -7,29 +7,31 @@ import (
 )
 
 type RectangularGrid struct {
-	colour  color.NRGBA
-	width   uint
-	height  uint
-	fit     Fit
-	overlay bool
+	gridBase
+	width  uint
+	height uint
+	fit    Fit
 }
 
 func NewRectangularGrid(colour color.NRGBA, width, height uint, fit Fit, overlay bool) RectangularGrid {
 	return RectangularGrid{
-		colour:  colour,
-		width:   width,
-		height:  height,
-		fit:     fit,
-		overlay: overlay,
+		gridBase: newGridBase(colour, overlay),
+		width:    width,
+		height:   height,
+		fit:      fit,
 	}
 }
 
-func (g RectangularGrid) Colour() color.NRGBA {
-	return g.colour
+func (g RectangularGrid) With(options ...Option) GridSpec {
+	for _, option := range options {
+		option(&g.gridBase)
+	}
+
+	return g
 }
 
-func (g RectangularGrid) Overlay() bool {
-	return g.overlay
+func (g RectangularGrid) Centre(bounds image.Rectangle, padding int) *int {
+	return centre(*g.Border(bounds, padding), g.baseline)
 }
 
 func (g RectangularGrid) Border(bounds image.Rectangle, padding int) *image.Rectangle {
@@ -50,6 +52,10 @@ func (g RectangularGrid) VLines(bounds image.Rectangle, padding int) []int {
 		x1 = border.Max.X
 	}
 
+	if g.origin != nil {
+		return tiled(x0, x1, *g.origin, spacing(g.width, g.fit))
+	}
+
 	N := float64(x1-x0) / float64(g.width)
 	dw := float64(x1-x0) / math.Round(N)
 
@@ -110,6 +116,10 @@ func (g RectangularGrid) HLines(bounds image.Rectangle, padding int) []int {
 		dw = math.Min(dw, float64(g.height))
 	}
 
+	if g.baseline != Centre {
+		return baselines(y0, y1, dw, g.baseline)
+	}
+
 	ym := float64(y1-y0+2*padding) / 2.0
 	if dw > 0 {
 		for line := 0; ; line++ {
@@ -7,27 +7,29 @@ import (
 )
 
 type SquareGrid struct {
-	colour  color.NRGBA
-	size    uint
-	fit     Fit
-	overlay bool
+	gridBase
+	size uint
+	fit  Fit
 }
 
 func NewSquareGrid(colour color.NRGBA, size uint, fit Fit, overlay bool) SquareGrid {
 	return SquareGrid{
-		colour:  colour,
-		size:    size,
-		fit:     fit,
-		overlay: overlay,
+		gridBase: newGridBase(colour, overlay),
+		size:     size,
+		fit:      fit,
 	}
 }
 
-func (g SquareGrid) Colour() color.NRGBA {
-	return g.colour
+func (g SquareGrid) With(options ...Option) GridSpec {
+	for _, option := range options {
+		option(&g.gridBase)
+	}
+
+	return g
 }
 
-func (g SquareGrid) Overlay() bool {
-	return g.overlay
+func (g SquareGrid) Centre(bounds image.Rectangle, padding int) *int {
+	return centre(*g.Border(bounds, padding), g.baseline)
 }
 
 func (g SquareGrid) Border(bounds image.Rectangle, padding int) *image.Rectangle {
@@ -48,6 +50,10 @@ func (g SquareGrid) VLines(bounds image.Rectangle, padding int) []int {
 		x1 = border.Max.X
 	}
 
+	if g.origin != nil {
+		return tiled(x0, x1, *g.origin, spacing(g.size, g.fit))
+	}
+
 	N := float64(x1-x0) / float64(g.size)
 	dw := float64(x1-x0) / math.Round(N)
 
@@ -108,6 +114,10 @@ func (g SquareGrid) HLines(bounds image.Rectangle, padding int) []int {
 		dw = math.Min(dw, float64(g.size))
 	}
 
+	if g.baseline != Centre {
+		return baselines(y0, y1, dw, g.baseline)
+	}
+
 	ym := float64(y1-y0+2*padding) / 2.0
 	if dw > 0 {
 		for line := 0; ; line++ {
@@ -110,6 +110,29 @@ func TestSquareGridHLinesWithNonIntegralSize(t *testing.T) {
 	}
 }
 
+func TestSquareGridHLinesWithBaseline(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+
+	tests := []struct {
+		baseline Baseline
+		expected []int
+	}{
+		{Centre, []int{192, 128, 64, 193, 257, 321}},
+		{Bottom, []int{321, 257, 193, 128, 64}},
+		{Top, []int{64, 128, 193, 257, 321}},
+	}
+
+	for _, v := range tests {
+		gridspec := WithBaseline(SquareGrid{size: 64}, v.baseline)
+
+		hlines := gridspec.HLines(bounds, padding)
+		if !reflect.DeepEqual(hlines, v.expected) {
+			t.Errorf("Incorrect horizontal lines for %v baseline:\n   expected:%v\n   got:     %v", v.baseline, v.expected, hlines)
+		}
+	}
+}
+
 func TestSquareGridApproximateFit(t *testing.T) {
 	tests := []struct {
 		bounds image.Rectangle
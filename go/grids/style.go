@@ -0,0 +1,150 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+	"sort"
+)
+
+// LineStyle is the colour, width (in pixels) and dash pattern of a grid line. The dash pattern
+// is a list of alternating 'on' and 'off' lengths (in pixels) e.g. [4,2] for a dashed line and
+// [1,2] for a dotted line. An empty dash pattern draws a solid line.
+type LineStyle struct {
+	Colour color.NRGBA
+	Width  uint
+	Dash   []uint
+}
+
+// GridStyle is the style of the grid lines:
+//   - every Interval'th line (counting from the left border and the baseline) is drawn with
+//     the Major style and the remaining lines with the Minor style. An interval of 0 draws all
+//     the lines with the Minor style.
+//   - the border is drawn with the Major style
+//   - the centre (zero amplitude) line is drawn with the Centre style, if not nil
+type GridStyle struct {
+	Minor    LineStyle
+	Major    LineStyle
+	Interval uint
+	Centre   *LineStyle
+}
+
+// WithStyle returns a copy of the grid with the line style.
+func WithStyle(spec GridSpec, style GridStyle) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.style = &style
+	})
+}
+
+// defaultStyle returns the grid style if not nil, or else single pixel solid lines in the grid colour.
+func defaultStyle(style *GridStyle, colour color.NRGBA) GridStyle {
+	if style != nil {
+		return *style
+	}
+
+	return GridStyle{
+		Minor: LineStyle{Colour: colour, Width: 1},
+		Major: LineStyle{Colour: colour, Width: 1},
+	}
+}
+
+// centre returns the position of the zero amplitude line for a baseline.
+func centre(border image.Rectangle, baseline Baseline) *int {
+	y := (border.Min.Y + border.Max.Y) / 2
+
+	switch baseline {
+	case Bottom:
+		y = border.Max.Y
+	case Top:
+		y = border.Min.Y
+	}
+
+	return &y
+}
+
+// majors flags every interval'th line on either side of the origin, ranking the lines on each side
+// by distance from the origin. Lines at the origin (to within a pixel, for a baseline between two
+// pixels) are ranked 0 i.e. are always major lines.
+func majors(lines []int, origin int, interval uint) []bool {
+	flags := make([]bool, len(lines))
+
+	if interval > 0 {
+		before := []int{}
+		after := []int{}
+		for _, v := range lines {
+			if v < origin-1 {
+				before = append(before, origin-v)
+			} else if v > origin+1 {
+				after = append(after, v-origin)
+			}
+		}
+
+		sort.Ints(before)
+		sort.Ints(after)
+
+		for i, v := range lines {
+			rank := 0
+			if v < origin-1 {
+				rank = 1 + sort.SearchInts(before, origin-v)
+			} else if v > origin+1 {
+				rank = 1 + sort.SearchInts(after, v-origin)
+			}
+
+			flags[i] = rank%int(interval) == 0
+		}
+	}
+
+	return flags
+}
+
+// vline draws a vertical line from y0 to y1 (inclusive) at x, scaled by N.
+func vline(img *image.NRGBA, x, y0, y1 int, style LineStyle, N int) {
+	for dx := N * offset(style.Width); dx < N*(offset(style.Width)+width(style.Width)); dx++ {
+		for y := N * y0; y < N*(y1+1); y++ {
+			if style.on((y - N*y0) / N) {
+				img.Set(N*x+dx, y, style.Colour)
+			}
+		}
+	}
+}
+
+// hline draws a horizontal line from x0 to x1 (inclusive) at y, scaled by N.
+func hline(img *image.NRGBA, y, x0, x1 int, style LineStyle, N int) {
+	for dy := N * offset(style.Width); dy < N*(offset(style.Width)+width(style.Width)); dy++ {
+		for x := N * x0; x < N*(x1+1); x++ {
+			if style.on((x - N*x0) / N) {
+				img.Set(x, N*y+dy, style.Colour)
+			}
+		}
+	}
+}
+
+// on returns true if the pixel at position p along the line is in an 'on' segment of the dash pattern.
+func (s LineStyle) on(p int) bool {
+	cycle := 0
+	for _, v := range s.Dash {
+		cycle += int(v)
+	}
+
+	if cycle == 0 {
+		return true
+	}
+
+	p %= cycle
+	for i, v := range s.Dash {
+		if p < int(v) {
+			return i%2 == 0
+		}
+
+		p -= int(v)
+	}
+
+	return false
+}
+
+func width(w uint) int {
+	return max(1, int(w))
+}
+
+func offset(w uint) int {
+	return -(width(w) - 1) / 2
+}
@@ -0,0 +1,99 @@
+package grids
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+)
+
+func TestLineStyleDash(t *testing.T) {
+	tests := []struct {
+		dash     []uint
+		expected []bool
+	}{
+		{nil, []bool{true, true, true, true, true, true}},
+		{[]uint{2, 1}, []bool{true, true, false, true, true, false}},
+		{[]uint{1, 2}, []bool{true, false, false, true, false, false}},
+		{[]uint{1, 1, 3, 1}, []bool{true, false, true, true, true, false}},
+	}
+
+	for _, test := range tests {
+		style := LineStyle{Dash: test.dash}
+		dashes := []bool{}
+		for p := 0; p < len(test.expected); p++ {
+			dashes = append(dashes, style.on(p))
+		}
+
+		if !reflect.DeepEqual(dashes, test.expected) {
+			t.Errorf("incorrect dash pattern for %v\n   expected:%v\n   got:     %v", test.dash, test.expected, dashes)
+		}
+	}
+}
+
+func TestMajors(t *testing.T) {
+	tests := []struct {
+		lines    []int
+		origin   int
+		interval uint
+		expected []bool
+	}{
+		{[]int{10, 20, 30, 40, 50}, 0, 2, []bool{false, true, false, true, false}},
+		{[]int{50, 40, 30, 20, 10}, 0, 2, []bool{false, true, false, true, false}},
+		{[]int{49, 33, 17, 50, 66, 82}, 49, 2, []bool{true, false, true, true, false, true}},
+		{[]int{10, 20, 30}, 0, 0, []bool{false, false, false}},
+	}
+
+	for _, test := range tests {
+		if flags := majors(test.lines, test.origin, test.interval); !reflect.DeepEqual(flags, test.expected) {
+			t.Errorf("incorrect major lines for %v\n   expected:%v\n   got:     %v", test.lines, test.expected, flags)
+		}
+	}
+}
+
+func TestGridStyle(t *testing.T) {
+	minor := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	major := color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	spec := WithStyle(NewSquareGrid(minor, 16, Exact, false), GridStyle{
+		Minor:    LineStyle{Colour: minor, Width: 1, Dash: []uint{1, 1}},
+		Major:    LineStyle{Colour: major, Width: 3},
+		Interval: 2,
+		Centre:   &LineStyle{Colour: white, Width: 1},
+	})
+
+	img := Grid(spec, 65, 65, 0)
+
+	// ... minor vertical line at 16 is dotted
+	if c := img.NRGBAAt(16, 4); c != minor {
+		t.Errorf("incorrect minor line colour - expected:%v, got:%v", minor, c)
+	}
+
+	if c := img.NRGBAAt(16, 5); c.A != 0 {
+		t.Errorf("expected gap in dotted minor line - got:%v", c)
+	}
+
+	// ... major vertical line at 32 is 3 pixels wide
+	for x := 31; x <= 33; x++ {
+		if c := img.NRGBAAt(x, 5); c != major {
+			t.Errorf("incorrect major line colour at %v - expected:%v, got:%v", x, major, c)
+		}
+	}
+
+	// ... centre line
+	if c := img.NRGBAAt(8, 32); c != white {
+		t.Errorf("incorrect centre line colour - expected:%v, got:%v", white, c)
+	}
+}
+
+func TestDefaultGridStyle(t *testing.T) {
+	colour := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	expected := GridStyle{
+		Minor: LineStyle{Colour: colour, Width: 1},
+		Major: LineStyle{Colour: colour, Width: 1},
+	}
+
+	if style := NewSquareGrid(colour, 64, Approximate, false).Style(); !reflect.DeepEqual(style, expected) {
+		t.Errorf("incorrect default grid style\n   expected:%v\n   got:     %v", expected, style)
+	}
+}
@@ -0,0 +1,341 @@
+package grids
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+	"time"
+)
+
+// YAxis is the (optional) vertical scale of a timeline grid.
+type YAxis int
+
+const (
+	NoAxis YAxis = iota
+	Amplitude
+	Decibels
+)
+
+func ParseYAxis(s string) (YAxis, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return NoAxis, nil
+	case "amplitude":
+		return Amplitude, nil
+	case "db":
+		return Decibels, nil
+	}
+
+	return NoAxis, fmt.Errorf("invalid y axis (%v)", s)
+}
+
+func (a YAxis) String() string {
+	return [...]string{"none", "amplitude", "db"}[a]
+}
+
+// TimelineGrid places the vertical grid lines at 'round' time intervals (1s, 5s, 1m, etc), chosen
+// so that the lines are 'spacing' pixels apart according to 'fit' for the time range of the rendered
+// audio. The horizontal grid lines (if any) follow an amplitude or dB scale.
+type TimelineGrid struct {
+	gridBase
+	spacing uint
+	fit     Fit
+	yaxis   YAxis
+	labels  bool
+}
+
+var intervals = []time.Duration{
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	15 * time.Second,
+	30 * time.Second,
+	1 * time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+	1 * time.Hour,
+}
+
+func NewTimelineGrid(colour color.NRGBA, spacing uint, fit Fit, yaxis YAxis, labels bool, overlay bool) TimelineGrid {
+	return TimelineGrid{
+		gridBase: newGridBase(colour, overlay),
+		spacing:  spacing,
+		fit:      fit,
+		yaxis:    yaxis,
+		labels:   labels,
+	}
+}
+
+// WithTimeRange returns a copy of the grid with the time range of the rendered audio, for time
+// aligned grids.
+func WithTimeRange(spec GridSpec, start, end time.Duration) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.start = start
+		b.end = end
+	})
+}
+
+// WithScale returns a copy of the grid with the vertical scaling of the rendered waveform, for grids
+// with an amplitude scale.
+func WithScale(spec GridSpec, vscale float64) GridSpec {
+	return with(spec, func(b *gridBase) {
+		b.vscale = vscale
+	})
+}
+
+func (g TimelineGrid) With(options ...Option) GridSpec {
+	for _, option := range options {
+		option(&g.gridBase)
+	}
+
+	return g
+}
+
+func (g TimelineGrid) Centre(bounds image.Rectangle, padding int) *int {
+	return centre(*g.Border(bounds, padding), g.baseline)
+}
+
+func (g TimelineGrid) Border(bounds image.Rectangle, padding int) *image.Rectangle {
+	border := image.Rect(bounds.Min.X+padding, bounds.Min.Y+padding, bounds.Max.X-1-padding, bounds.Max.Y-1-padding)
+
+	return &border
+}
+
+func (g TimelineGrid) VLines(bounds image.Rectangle, padding int) []int {
+	vlines := []int{}
+	for _, tick := range g.vticks(bounds, padding) {
+		vlines = append(vlines, tick.at)
+	}
+
+	return vlines
+}
+
+func (g TimelineGrid) HLines(bounds image.Rectangle, padding int) []int {
+	hlines := []int{}
+	for _, tick := range g.hticks(bounds, padding) {
+		hlines = append(hlines, tick.at)
+	}
+
+	return hlines
+}
+
+// Labels returns the time labels for the vertical lines and the amplitude or dB labels for the
+// horizontal lines, if the grid is labelled.
+func (g TimelineGrid) Labels(bounds image.Rectangle, padding int) []Label {
+	labels := []Label{}
+
+	if g.labels {
+		border := g.Border(bounds, padding)
+
+		for _, tick := range g.vticks(bounds, padding) {
+			labels = append(labels, Label{Text: tick.label, X: tick.at + 3, Y: border.Max.Y - 3, Vertical: true})
+		}
+
+		for _, tick := range g.hticks(bounds, padding) {
+			labels = append(labels, Label{Text: tick.label, X: border.Min.X + 3, Y: tick.at - 2})
+		}
+	}
+
+	return labels
+}
+
+type tick struct {
+	at    int
+	label string
+}
+
+func (g TimelineGrid) vticks(bounds image.Rectangle, padding int) []tick {
+	ticks := []tick{}
+	border := g.Border(bounds, padding)
+	x0 := border.Min.X
+	x1 := border.Max.X
+	duration := g.end - g.start
+
+	if duration <= 0 || x1 <= x0 {
+		return ticks
+	}
+
+	// ... a tile spans the full image width and includes the lines at the left edge
+	first := g.start + 1
+	if g.origin != nil {
+		x1++
+		first = max(g.start, 1)
+	}
+
+	pps := float64(x1-x0) / duration.Seconds()
+	interval := g.interval(pps)
+
+	for t := ((first + interval - 1) / interval) * interval; t < g.end; t += interval {
+		x := x0 + int(math.Round((t-g.start).Seconds()*pps))
+		if (x > x0 || (g.origin != nil && x == x0)) && x < x1 {
+			ticks = append(ticks, tick{at: x, label: timestamp(t, interval)})
+		}
+	}
+
+	return ticks
+}
+
+// interval returns the time interval for which the vertical lines are closest to 'spacing' pixels
+// apart at pps pixels per second or, for the bounded fits, the closest interval within the bound. The
+// intervals are 'round' times so an Exact fit is treated as Approximate.
+func (g TimelineGrid) interval(pps float64) time.Duration {
+	size := float64(g.spacing)
+
+	switch g.fit {
+	case AtLeast, LargerThan:
+		for _, v := range intervals {
+			if dx := v.Seconds() * pps; dx > size || (dx == size && g.fit == AtLeast) {
+				return v
+			}
+		}
+
+		return intervals[len(intervals)-1]
+
+	case AtMost, SmallerThan:
+		for i := len(intervals) - 1; i >= 0; i-- {
+			if dx := intervals[i].Seconds() * pps; dx < size || (dx == size && g.fit == AtMost) {
+				return intervals[i]
+			}
+		}
+
+		return intervals[0]
+	}
+
+	interval := intervals[0]
+	for _, v := range intervals[1:] {
+		if math.Abs(v.Seconds()*pps-size) < math.Abs(interval.Seconds()*pps-size) {
+			interval = v
+		}
+	}
+
+	return interval
+}
+
+func (g TimelineGrid) hticks(bounds image.Rectangle, padding int) []tick {
+	border := g.Border(bounds, padding)
+
+	switch g.yaxis {
+	case Amplitude:
+		return amplitudes(border.Min.Y, border.Max.Y, g.vscale, g.baseline)
+
+	case Decibels:
+		return decibels(border.Min.Y, border.Max.Y, g.vscale, g.baseline)
+	}
+
+	return []tick{}
+}
+
+// amplitudes lays out horizontal ticks at amplitude intervals of 0.25, 0.5 or 1.0, whichever
+// is the first to space the lines at least 16 pixels apart.
+func amplitudes(y0, y1 int, vscale float64, baseline Baseline) []tick {
+	ticks := []tick{}
+	step := 1.0
+
+	for _, v := range []float64{0.25, 0.5} {
+		if _, dy := level(y0, y1, v, vscale, baseline); dy >= 16 {
+			step = v
+			break
+		}
+	}
+
+	for a := step; ; a += step {
+		y, dy := level(y0, y1, a, vscale, baseline)
+		if dy <= 0 || len(y) == 0 {
+			break
+		}
+
+		ticks = append(ticks, tick{at: y[0], label: fmt.Sprintf("%.2g", a)})
+		if len(y) > 1 {
+			ticks = append(ticks, tick{at: y[1], label: fmt.Sprintf("%.2g", -a)})
+		}
+	}
+
+	return ticks
+}
+
+// decibels lays out horizontal ticks at -6dB intervals (relative to full scale), stopping when the
+// lines would be less than 16 pixels apart.
+func decibels(y0, y1 int, vscale float64, baseline Baseline) []tick {
+	ticks := []tick{}
+	last := math.MaxInt
+
+	for dB := 0; dB >= -96; dB -= 6 {
+		y, dy := level(y0, y1, math.Pow(10, float64(dB)/20), vscale, baseline)
+		if dy <= 0 || last-dy < 16 {
+			break
+		}
+
+		last = dy
+		for _, v := range y {
+			ticks = append(ticks, tick{at: v, label: fmt.Sprintf("%vdB", dB)})
+		}
+	}
+
+	return ticks
+}
+
+// level returns the y positions (inside the border) of the lines for an amplitude (relative to full
+// scale) along with the distance from the baseline. A centre baseline has lines above and below
+// the baseline.
+func level(y0, y1 int, amplitude, vscale float64, baseline Baseline) ([]int, int) {
+	y := []int{}
+
+	switch baseline {
+	case Bottom:
+		dy := int(math.Round(amplitude * vscale * float64(y1-y0)))
+		if v := y1 - dy; v > y0 {
+			y = append(y, v)
+		}
+
+		return y, dy
+
+	case Top:
+		dy := int(math.Round(amplitude * vscale * float64(y1-y0)))
+		if v := y0 + dy; v < y1 {
+			y = append(y, v)
+		}
+
+		return y, dy
+
+	default:
+		ym := float64(y0+y1) / 2.0
+		dy := int(math.Round(amplitude * vscale * float64(y1-y0) / 2.0))
+		if v := int(math.Floor(ym)) - dy; v > y0 {
+			y = append(y, v)
+		}
+
+		if v := int(math.Ceil(ym)) + dy; v < y1 {
+			y = append(y, v)
+		}
+
+		return y, dy
+	}
+}
+
+// timestamp formats a time as m:ss (or h:mm:ss), with tenths of a second for sub-second intervals.
+func timestamp(t time.Duration, interval time.Duration) string {
+	h := int(t / time.Hour)
+	m := int(t/time.Minute) % 60
+	s := int(t/time.Second) % 60
+	ds := int(t/(100*time.Millisecond)) % 10
+
+	switch {
+	case interval < time.Second && h > 0:
+		return fmt.Sprintf("%d:%02d:%02d.%d", h, m, s, ds)
+	case interval < time.Second:
+		return fmt.Sprintf("%d:%02d.%d", m, s, ds)
+	case h > 0:
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	default:
+		return fmt.Sprintf("%d:%02d", m, s)
+	}
+}
@@ -0,0 +1,146 @@
+package grids
+
+import (
+	"image"
+	"image/color"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimelineGridVLines(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{64, 128, 192, 256, 320, 384, 448, 512, 576}
+
+	gridspec := WithTimeRange(TimelineGrid{spacing: 64}, 0, 10*time.Second)
+
+	vlines := gridspec.VLines(bounds, padding)
+	if !reflect.DeepEqual(vlines, expected) {
+		t.Errorf("Incorrect vertical lines:\n   expected:%v\n   got:     %v", expected, vlines)
+	}
+}
+
+func TestTimelineGridVLinesWithOffset(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{64, 192, 320, 448, 576}
+
+	gridspec := WithTimeRange(TimelineGrid{spacing: 128}, 55*time.Second, 65*time.Second)
+
+	vlines := gridspec.VLines(bounds, padding)
+	if !reflect.DeepEqual(vlines, expected) {
+		t.Errorf("Incorrect vertical lines:\n   expected:%v\n   got:     %v", expected, vlines)
+	}
+}
+
+func TestTimelineGridVLinesWithFit(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+
+	tests := []struct {
+		spacing  uint
+		fit      Fit
+		expected []int
+	}{
+		{100, Approximate, []int{128, 256, 384, 512}},
+		{100, Exact, []int{128, 256, 384, 512}},
+		{100, AtLeast, []int{128, 256, 384, 512}},
+		{100, AtMost, []int{64, 128, 192, 256, 320, 384, 448, 512, 576}},
+		{128, AtLeast, []int{128, 256, 384, 512}},
+		{128, LargerThan, []int{320}},
+		{128, AtMost, []int{128, 256, 384, 512}},
+		{128, SmallerThan, []int{64, 128, 192, 256, 320, 384, 448, 512, 576}},
+	}
+
+	for _, test := range tests {
+		gridspec := WithTimeRange(TimelineGrid{spacing: test.spacing, fit: test.fit}, 0, 10*time.Second)
+
+		if vlines := gridspec.VLines(bounds, padding); !reflect.DeepEqual(vlines, test.expected) {
+			t.Errorf("Incorrect vertical lines for fit %v%v:\n   expected:%v\n   got:     %v", test.fit, test.spacing, test.expected, vlines)
+		}
+	}
+}
+
+func TestTimelineGridWithoutTimeRange(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	var gridspec GridSpec = TimelineGrid{spacing: 64}
+
+	if vlines := gridspec.VLines(bounds, padding); len(vlines) != 0 {
+		t.Errorf("Unexpected vertical lines %v", vlines)
+	}
+}
+
+func TestTimelineGridDecibels(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{96, 289, 144, 241, 168, 217}
+
+	gridspec := WithScale(TimelineGrid{spacing: 64, yaxis: Decibels}, 1.0)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestTimelineGridAmplitude(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	expected := []int{144, 241, 96, 289, 48, 337}
+
+	gridspec := WithScale(TimelineGrid{spacing: 64, yaxis: Amplitude}, 1.0)
+
+	hlines := gridspec.HLines(bounds, padding)
+	if !reflect.DeepEqual(hlines, expected) {
+		t.Errorf("Incorrect horizontal lines:\n   expected:%v\n   got:     %v", expected, hlines)
+	}
+}
+
+func TestTimelineGridLabels(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+	padding := 0
+	colour := color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
+	expected := []Label{
+		{Text: "0:59", X: 259, Y: 382, Vertical: true},
+		{Text: "1:00", X: 323, Y: 382, Vertical: true},
+		{Text: "1:01", X: 387, Y: 382, Vertical: true},
+		{Text: "-12dB", X: 3, Y: 93},
+		{Text: "-12dB", X: 3, Y: 288},
+	}
+
+	gridspec := WithTimeRange(NewTimelineGrid(colour, 64, Approximate, Decibels, true, false), 55*time.Second, 65*time.Second)
+	gridspec = WithScale(gridspec, 2.0)
+
+	labels := gridspec.(Labelled).Labels(bounds, padding)
+	for _, label := range expected {
+		found := false
+		for _, l := range labels {
+			found = found || l == label
+		}
+
+		if !found {
+			t.Errorf("Missing label %v:\n   got:%v", label, labels)
+		}
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		t        time.Duration
+		interval time.Duration
+		expected string
+	}{
+		{5 * time.Second, time.Second, "0:05"},
+		{75 * time.Second, 15 * time.Second, "1:15"},
+		{1500 * time.Millisecond, 500 * time.Millisecond, "0:01.5"},
+		{time.Hour + 2*time.Minute, time.Minute, "1:02:00"},
+	}
+
+	for _, test := range tests {
+		if s := timestamp(test.t, test.interval); s != test.expected {
+			t.Errorf("incorrect timestamp for %v - expected:%v, got:%v", test.t, test.expected, s)
+		}
+	}
+}
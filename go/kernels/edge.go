@@ -0,0 +1,62 @@
+package kernels
+
+import (
+	"fmt"
+)
+
+// Edge determines the pixel values used for the parts of a kernel that extend past the edges
+// of the image.
+type Edge int
+
+const (
+	Clamp Edge = iota
+	Mirror
+)
+
+func ParseEdge(s string) (Edge, error) {
+	switch s {
+	case "", "clamp":
+		return Clamp, nil
+
+	case "mirror":
+		return Mirror, nil
+
+	default:
+		return Clamp, fmt.Errorf("invalid edge mode (%v)", s)
+	}
+}
+
+func (e Edge) String() string {
+	switch e {
+	case Mirror:
+		return "mirror"
+
+	default:
+		return "clamp"
+	}
+}
+
+// resolve maps a (possibly out of range) coordinate to a coordinate in the range [0,N).
+func (e Edge) resolve(v, N int) int {
+	switch e {
+	case Mirror:
+		if N < 2 {
+			return 0
+		}
+
+		period := 2 * (N - 1)
+		v %= period
+		if v < 0 {
+			v += period
+		}
+
+		if v >= N {
+			v = period - v
+		}
+
+		return v
+
+	default:
+		return max(0, min(N-1, v))
+	}
+}
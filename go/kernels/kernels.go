@@ -1,36 +1,132 @@
 package kernels
 
 import (
+	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
+	"math"
 )
 
-type Kernel [3][3]uint32
-
-var None = Kernel{
-	{0, 0, 0},
-	{0, 1, 0},
-	{0, 0, 0},
+// Kernel is a convolution kernel, defined either as an N×N matrix (N odd) or as a separable pair
+// of horizontal and vertical 1D kernels. The zero value is the identity kernel.
+type Kernel struct {
+	matrix     [][]float64
+	horizontal []float64
+	vertical   []float64
+	edge       Edge
 }
 
+var None = Kernel{}
+
 var Vertical = Kernel{
-	{0, 1, 0},
-	{0, 2, 0},
-	{0, 1, 0},
+	horizontal: []float64{1},
+	vertical:   []float64{1, 2, 1},
 }
 
 var Horizontal = Kernel{
-	{0, 0, 0},
-	{1, 2, 1},
-	{0, 0, 0},
+	horizontal: []float64{1, 2, 1},
+	vertical:   []float64{1},
 }
 
 var Soft = Kernel{
-	{1, 2, 1},
-	{2, 12, 2},
-	{1, 2, 1},
+	matrix: [][]float64{
+		{1, 2, 1},
+		{2, 12, 2},
+		{1, 2, 1},
+	},
+}
+
+// NewKernel returns a kernel for an N×N matrix of weights. The matrix must be square with an odd
+// number of rows and the weights must sum to a positive value.
+func NewKernel(matrix [][]float64) (Kernel, error) {
+	N := len(matrix)
+	if N == 0 || N%2 == 0 {
+		return None, fmt.Errorf("invalid kernel size (%v)", N)
+	}
+
+	sum := 0.0
+	for _, row := range matrix {
+		if len(row) != N {
+			return None, fmt.Errorf("invalid kernel - expected %vx%v matrix", N, N)
+		}
+
+		for _, k := range row {
+			sum += k
+		}
+	}
+
+	if sum <= 0 {
+		return None, fmt.Errorf("invalid kernel weights (%v)", sum)
+	}
+
+	return Kernel{matrix: clone(matrix)}, nil
 }
 
+// NewSeparable returns a separable kernel that is applied as a horizontal pass followed by a
+// vertical pass. Both kernels must have an odd number of weights that sum to a positive value.
+func NewSeparable(horizontal, vertical []float64) (Kernel, error) {
+	for _, v := range [][]float64{horizontal, vertical} {
+		if len(v) == 0 || len(v)%2 == 0 {
+			return None, fmt.Errorf("invalid kernel size (%v)", len(v))
+		}
+
+		if sum := total(v); sum <= 0 {
+			return None, fmt.Errorf("invalid kernel weights (%v)", sum)
+		}
+	}
+
+	return Kernel{
+		horizontal: append([]float64{}, horizontal...),
+		vertical:   append([]float64{}, vertical...),
+	}, nil
+}
+
+// Gaussian returns a separable Gaussian blur kernel with the standard deviation sigma (in pixels),
+// truncated at 3 sigma.
+func Gaussian(sigma float64) (Kernel, error) {
+	if sigma <= 0 || math.IsNaN(sigma) || math.IsInf(sigma, 0) {
+		return None, fmt.Errorf("invalid Gaussian sigma (%v)", sigma)
+	}
+
+	r := int(math.Ceil(3 * sigma))
+	weights := make([]float64, 2*r+1)
+	for i := range weights {
+		x := float64(i - r)
+		weights[i] = math.Exp(-x * x / (2 * sigma * sigma))
+	}
+
+	return NewSeparable(weights, weights)
+}
+
+// Box returns a separable size×size box blur kernel.
+func Box(size int) (Kernel, error) {
+	if size < 1 || size%2 == 0 {
+		return None, fmt.Errorf("invalid box size (%v)", size)
+	}
+
+	weights := make([]float64, size)
+	for i := range weights {
+		weights[i] = 1
+	}
+
+	return NewSeparable(weights, weights)
+}
+
+// WithEdge returns a copy of the kernel that uses the edge mode for pixels outside the image.
+func (k Kernel) WithEdge(edge Edge) Kernel {
+	k.edge = edge
+
+	return k
+}
+
+func (k Kernel) Edge() Edge {
+	return k.edge
+}
+
+// Antialias convolves the image with the kernel. The convolution is calculated with premultiplied
+// alpha so that transparent pixels do not darken the edges of the image content, with pixels
+// outside the image resolved by the kernel edge mode.
 func Antialias(img *image.NRGBA, kernel Kernel) *image.NRGBA {
 	w := img.Bounds().Dx()
 	h := img.Bounds().Dy()
@@ -40,34 +136,143 @@ func Antialias(img *image.NRGBA, kernel Kernel) *image.NRGBA {
 		Max: image.Pt(w, h),
 	})
 
-	N := uint32(0)
-	for _, row := range kernel {
-		for _, k := range row {
-			N += k
+	if kernel.identity() {
+		draw.Draw(out, out.Bounds(), img, image.Pt(0, 0), draw.Src)
+		return out
+	}
+
+	pixels := premultiply(img, w, h)
+
+	if kernel.matrix != nil {
+		pixels = convolve(pixels, w, h, kernel.matrix, kernel.edge)
+	} else {
+		if len(kernel.horizontal) > 1 {
+			pixels = convolve(pixels, w, h, [][]float64{kernel.horizontal}, kernel.edge)
+		}
+
+		if len(kernel.vertical) > 1 {
+			pixels = convolve(pixels, w, h, transpose(kernel.vertical), kernel.edge)
+		}
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			out.SetNRGBA(x, y, unpremultiply(pixels[y*w+x]))
+		}
+	}
+
+	return out
+}
+
+func (k Kernel) identity() bool {
+	if k.matrix != nil {
+		return false
+	}
+
+	return len(k.horizontal) <= 1 && len(k.vertical) <= 1
+}
+
+// premultiply returns the pixels of the image as premultiplied alpha RGBA values. The pixels are
+// read from (0,0) to (w,h) in image coordinates (for consistency with images that are sub-images).
+func premultiply(img *image.NRGBA, w, h int) [][4]float64 {
+	pixels := make([][4]float64, w*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			u := img.NRGBAAt(x, y)
+			a := float64(u.A) / 255
+
+			pixels[y*w+x] = [4]float64{
+				a * float64(u.R),
+				a * float64(u.G),
+				a * float64(u.B),
+				float64(u.A),
+			}
 		}
 	}
 
-	for x := 0; x < w; x++ {
-		for y := 0; y < h; y++ {
-			r := uint32(0)
-			g := uint32(0)
-			b := uint32(0)
-			a := uint32(0)
+	return pixels
+}
+
+func unpremultiply(p [4]float64) color.NRGBA {
+	a := clamp(p[3])
+	if a == 0 {
+		return color.NRGBA{}
+	}
+
+	return color.NRGBA{
+		R: clamp(255 * p[0] / p[3]),
+		G: clamp(255 * p[1] / p[3]),
+		B: clamp(255 * p[2] / p[3]),
+		A: a,
+	}
+}
+
+// convolve convolves the premultiplied pixels with an M×N kernel (M and N odd), normalised by the
+// sum of the weights.
+func convolve(pixels [][4]float64, w, h int, kernel [][]float64, edge Edge) [][4]float64 {
+	out := make([][4]float64, w*h)
+	rows := len(kernel)
+	cols := len(kernel[0])
+	N := 0.0
+
+	for _, row := range kernel {
+		N += total(row)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var p [4]float64
 
 			for i, row := range kernel {
+				v := edge.resolve(y+i-rows/2, h)
 				for j, k := range row {
-					u := img.NRGBAAt(x+j-1, y+i-1)
+					if k != 0 {
+						u := edge.resolve(x+j-cols/2, w)
+						q := pixels[v*w+u]
 
-					r += k * uint32(u.R)
-					g += k * uint32(u.G)
-					b += k * uint32(u.B)
-					a += k * uint32(u.A)
+						p[0] += k * q[0]
+						p[1] += k * q[1]
+						p[2] += k * q[2]
+						p[3] += k * q[3]
+					}
 				}
 			}
 
-			out.SetNRGBA(x, y, color.NRGBA{R: uint8(r / N), G: uint8(g / N), B: uint8(b / N), A: uint8(a / N)})
+			out[y*w+x] = [4]float64{p[0] / N, p[1] / N, p[2] / N, p[3] / N}
 		}
 	}
 
 	return out
 }
+
+func transpose(v []float64) [][]float64 {
+	matrix := make([][]float64, len(v))
+	for i, k := range v {
+		matrix[i] = []float64{k}
+	}
+
+	return matrix
+}
+
+func clone(matrix [][]float64) [][]float64 {
+	c := make([][]float64, len(matrix))
+	for i, row := range matrix {
+		c[i] = append([]float64{}, row...)
+	}
+
+	return c
+}
+
+func total(v []float64) float64 {
+	sum := 0.0
+	for _, k := range v {
+		sum += k
+	}
+
+	return sum
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
@@ -24,9 +24,9 @@ func TestSinglePixelVerticalAntiAlias(t *testing.T) {
 	img := makeSinglePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 3, 3))
 
-	expected.Set(1, 0, color.NRGBA{R: 0x3f, G: 0x3f, B: 0x3f, A: 0x3f})
-	expected.Set(1, 1, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(1, 2, color.NRGBA{R: 0x3f, G: 0x3f, B: 0x3f, A: 0x3f})
+	expected.Set(1, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x40})
+	expected.Set(1, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80})
+	expected.Set(1, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x40})
 
 	result := Antialias(img, Vertical)
 
@@ -39,9 +39,9 @@ func TestSinglePixelHorizontalAntiAlias(t *testing.T) {
 	img := makeSinglePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 3, 3))
 
-	expected.Set(0, 1, color.NRGBA{R: 0x3f, G: 0x3f, B: 0x3f, A: 0x3f})
-	expected.Set(1, 1, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(2, 1, color.NRGBA{R: 0x3f, G: 0x3f, B: 0x3f, A: 0x3f})
+	expected.Set(0, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x40})
+	expected.Set(1, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80})
+	expected.Set(2, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x40})
 
 	result := Antialias(img, Horizontal)
 
@@ -54,17 +54,17 @@ func TestSinglePixelSoftAntiAlias(t *testing.T) {
 	img := makeSinglePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 3, 3))
 
-	expected.Set(0, 0, color.NRGBA{R: 0x0a, G: 0x0a, B: 0x0a, A: 0x0a})
-	expected.Set(0, 1, color.NRGBA{R: 0x15, G: 0x15, B: 0x15, A: 0x15})
-	expected.Set(0, 2, color.NRGBA{R: 0x0a, G: 0x0a, B: 0x0a, A: 0x0a})
+	expected.Set(0, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x0b})
+	expected.Set(0, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x15})
+	expected.Set(0, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x0b})
 
-	expected.Set(1, 0, color.NRGBA{R: 0x15, G: 0x15, B: 0x15, A: 0x15})
-	expected.Set(1, 1, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(1, 2, color.NRGBA{R: 0x15, G: 0x15, B: 0x15, A: 0x15})
+	expected.Set(1, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x15})
+	expected.Set(1, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80})
+	expected.Set(1, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x15})
 
-	expected.Set(2, 0, color.NRGBA{R: 0x0a, G: 0x0a, B: 0x0a, A: 0x0a})
-	expected.Set(2, 1, color.NRGBA{R: 0x15, G: 0x15, B: 0x15, A: 0x15})
-	expected.Set(2, 2, color.NRGBA{R: 0x0a, G: 0x0a, B: 0x0a, A: 0x0a})
+	expected.Set(2, 0, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x0b})
+	expected.Set(2, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x15})
+	expected.Set(2, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x0b})
 
 	result := Antialias(img, Soft)
 
@@ -118,35 +118,35 @@ func TestFivePixelVerticalAntiAlias(t *testing.T) {
 	img := makeFivePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 5, 5))
 
-	expected.Set(0, 0, color.NRGBA{R: 0x28, G: 0x28, B: 0x28, A: 0x28})
-	expected.Set(0, 1, color.NRGBA{R: 0x50, G: 0x50, B: 0x50, A: 0x50})
+	expected.Set(0, 0, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x30})
+	expected.Set(0, 1, color.NRGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0x50})
 	expected.Set(0, 2, color.NRGBA{R: 0x60, G: 0x60, B: 0x60, A: 0x60})
-	expected.Set(0, 3, color.NRGBA{R: 0x50, G: 0x50, B: 0x50, A: 0x50})
-	expected.Set(0, 4, color.NRGBA{R: 0x28, G: 0x28, B: 0x28, A: 0x28})
+	expected.Set(0, 3, color.NRGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0x50})
+	expected.Set(0, 4, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x30})
 
-	expected.Set(1, 0, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(1, 1, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
+	expected.Set(1, 0, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
+	expected.Set(1, 1, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
 	expected.Set(1, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
-	expected.Set(1, 3, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
-	expected.Set(1, 4, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
+	expected.Set(1, 3, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
+	expected.Set(1, 4, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
 
-	expected.Set(2, 0, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(2, 1, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
+	expected.Set(2, 0, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
+	expected.Set(2, 1, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
 	expected.Set(2, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
-	expected.Set(2, 3, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
-	expected.Set(2, 4, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
+	expected.Set(2, 3, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
+	expected.Set(2, 4, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
 
-	expected.Set(3, 0, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(3, 1, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
+	expected.Set(3, 0, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
+	expected.Set(3, 1, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
 	expected.Set(3, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
-	expected.Set(3, 3, color.NRGBA{R: 0xdf, G: 0xdf, B: 0xdf, A: 0xdf})
-	expected.Set(3, 4, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
+	expected.Set(3, 3, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xdf})
+	expected.Set(3, 4, color.NRGBA{R: 0xb3, G: 0xb3, B: 0xb3, A: 0xa0})
 
-	expected.Set(4, 0, color.NRGBA{R: 0x28, G: 0x28, B: 0x28, A: 0x28})
-	expected.Set(4, 1, color.NRGBA{R: 0x50, G: 0x50, B: 0x50, A: 0x50})
+	expected.Set(4, 0, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x30})
+	expected.Set(4, 1, color.NRGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0x50})
 	expected.Set(4, 2, color.NRGBA{R: 0x60, G: 0x60, B: 0x60, A: 0x60})
-	expected.Set(4, 3, color.NRGBA{R: 0x50, G: 0x50, B: 0x50, A: 0x50})
-	expected.Set(4, 4, color.NRGBA{R: 0x28, G: 0x28, B: 0x28, A: 0x28})
+	expected.Set(4, 3, color.NRGBA{R: 0x5a, G: 0x5a, B: 0x5a, A: 0x50})
+	expected.Set(4, 4, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x30})
 
 	result := Antialias(img, Vertical)
 
@@ -159,35 +159,35 @@ func TestFivePixelHorizontalAntiAlias(t *testing.T) {
 	img := makeFivePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 5, 5))
 
-	expected.Set(0, 0, color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0x30})
-	expected.Set(0, 1, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(0, 2, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(0, 3, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(0, 4, color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0x30})
+	expected.Set(0, 0, color.NRGBA{R: 0x57, G: 0x57, B: 0x57, A: 0x38})
+	expected.Set(0, 1, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(0, 2, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(0, 3, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(0, 4, color.NRGBA{R: 0x57, G: 0x57, B: 0x57, A: 0x38})
 
-	expected.Set(1, 0, color.NRGBA{R: 0x68, G: 0x68, B: 0x68, A: 0x68})
-	expected.Set(1, 1, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(1, 2, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(1, 3, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(1, 4, color.NRGBA{R: 0x68, G: 0x68, B: 0x68, A: 0x68})
+	expected.Set(1, 0, color.NRGBA{R: 0x79, G: 0x79, B: 0x79, A: 0x68})
+	expected.Set(1, 1, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(1, 2, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(1, 3, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(1, 4, color.NRGBA{R: 0x79, G: 0x79, B: 0x79, A: 0x68})
 
 	expected.Set(2, 0, color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80})
 	expected.Set(2, 1, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
-	expected.Set(2, 3, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
 	expected.Set(2, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
+	expected.Set(2, 3, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
 	expected.Set(2, 4, color.NRGBA{R: 0x80, G: 0x80, B: 0x80, A: 0x80})
 
-	expected.Set(3, 0, color.NRGBA{R: 0x68, G: 0x68, B: 0x68, A: 0x68})
-	expected.Set(3, 2, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(3, 1, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(3, 3, color.NRGBA{R: 0xd7, G: 0xd7, B: 0xd7, A: 0xd7})
-	expected.Set(3, 4, color.NRGBA{R: 0x68, G: 0x68, B: 0x68, A: 0x68})
+	expected.Set(3, 0, color.NRGBA{R: 0x79, G: 0x79, B: 0x79, A: 0x68})
+	expected.Set(3, 1, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(3, 2, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(3, 3, color.NRGBA{R: 0xed, G: 0xed, B: 0xed, A: 0xd7})
+	expected.Set(3, 4, color.NRGBA{R: 0x79, G: 0x79, B: 0x79, A: 0x68})
 
-	expected.Set(4, 0, color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0x30})
-	expected.Set(4, 1, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(4, 2, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(4, 3, color.NRGBA{R: 0x6f, G: 0x6f, B: 0x6f, A: 0x6f})
-	expected.Set(4, 4, color.NRGBA{R: 0x30, G: 0x30, B: 0x30, A: 0x30})
+	expected.Set(4, 0, color.NRGBA{R: 0x57, G: 0x57, B: 0x57, A: 0x38})
+	expected.Set(4, 1, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(4, 2, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(4, 3, color.NRGBA{R: 0xab, G: 0xab, B: 0xab, A: 0x88})
+	expected.Set(4, 4, color.NRGBA{R: 0x57, G: 0x57, B: 0x57, A: 0x38})
 
 	result := Antialias(img, Horizontal)
 
@@ -200,35 +200,35 @@ func TestFivePixelSoftAntiAlias(t *testing.T) {
 	img := makeFivePixelImage()
 	expected := image.NewNRGBA(image.Rect(0, 0, 5, 5))
 
-	expected.Set(0, 0, color.NRGBA{R: 0x2d, G: 0x2d, B: 0x2d, A: 0x2d})
-	expected.Set(0, 1, color.NRGBA{R: 0x5f, G: 0x5f, B: 0x5f, A: 0x5f})
-	expected.Set(0, 2, color.NRGBA{R: 0x6a, G: 0x6a, B: 0x6a, A: 0x6a})
-	expected.Set(0, 3, color.NRGBA{R: 0x5f, G: 0x5f, B: 0x5f, A: 0x5f})
-	expected.Set(0, 4, color.NRGBA{R: 0x2d, G: 0x2d, B: 0x2d, A: 0x2d})
+	expected.Set(0, 0, color.NRGBA{R: 0x6c, G: 0x6c, B: 0x6c, A: 0x3d})
+	expected.Set(0, 1, color.NRGBA{R: 0x8e, G: 0x8e, B: 0x8e, A: 0x6d})
+	expected.Set(0, 2, color.NRGBA{R: 0x97, G: 0x97, B: 0x97, A: 0x7b})
+	expected.Set(0, 3, color.NRGBA{R: 0x8e, G: 0x8e, B: 0x8e, A: 0x6d})
+	expected.Set(0, 4, color.NRGBA{R: 0x6c, G: 0x6c, B: 0x6c, A: 0x3d})
 
-	expected.Set(1, 0, color.NRGBA{R: 0x71, G: 0x71, B: 0x71, A: 0x71})
-	expected.Set(1, 1, color.NRGBA{R: 0xd1, G: 0xd1, B: 0xd1, A: 0xd1})
-	expected.Set(1, 2, color.NRGBA{R: 0xe4, G: 0xe4, B: 0xe4, A: 0xe4})
-	expected.Set(1, 3, color.NRGBA{R: 0xd1, G: 0xd1, B: 0xd1, A: 0xd1})
-	expected.Set(1, 4, color.NRGBA{R: 0x71, G: 0x71, B: 0x71, A: 0x71})
+	expected.Set(1, 0, color.NRGBA{R: 0x9b, G: 0x9b, B: 0x9b, A: 0x83})
+	expected.Set(1, 1, color.NRGBA{R: 0xeb, G: 0xeb, B: 0xeb, A: 0xd2})
+	expected.Set(1, 2, color.NRGBA{R: 0xf4, G: 0xf4, B: 0xf4, A: 0xe5})
+	expected.Set(1, 3, color.NRGBA{R: 0xeb, G: 0xeb, B: 0xeb, A: 0xd2})
+	expected.Set(1, 4, color.NRGBA{R: 0x9b, G: 0x9b, B: 0x9b, A: 0x83})
 
-	expected.Set(2, 0, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
-	expected.Set(2, 1, color.NRGBA{R: 0xe9, G: 0xe9, B: 0xe9, A: 0xe9})
+	expected.Set(2, 0, color.NRGBA{R: 0xa4, G: 0xa4, B: 0xa4, A: 0x95})
+	expected.Set(2, 1, color.NRGBA{R: 0xf3, G: 0xf3, B: 0xf3, A: 0xea})
 	expected.Set(2, 2, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff})
-	expected.Set(2, 3, color.NRGBA{R: 0xe9, G: 0xe9, B: 0xe9, A: 0xe9})
-	expected.Set(2, 4, color.NRGBA{R: 0x7f, G: 0x7f, B: 0x7f, A: 0x7f})
+	expected.Set(2, 3, color.NRGBA{R: 0xf3, G: 0xf3, B: 0xf3, A: 0xea})
+	expected.Set(2, 4, color.NRGBA{R: 0xa4, G: 0xa4, B: 0xa4, A: 0x95})
 
-	expected.Set(3, 0, color.NRGBA{R: 0x71, G: 0x71, B: 0x71, A: 0x71})
-	expected.Set(3, 1, color.NRGBA{R: 0xd1, G: 0xd1, B: 0xd1, A: 0xd1})
-	expected.Set(3, 2, color.NRGBA{R: 0xe4, G: 0xe4, B: 0xe4, A: 0xe4})
-	expected.Set(3, 3, color.NRGBA{R: 0xd1, G: 0xd1, B: 0xd1, A: 0xd1})
-	expected.Set(3, 4, color.NRGBA{R: 0x71, G: 0x71, B: 0x71, A: 0x71})
+	expected.Set(3, 0, color.NRGBA{R: 0x9b, G: 0x9b, B: 0x9b, A: 0x83})
+	expected.Set(3, 1, color.NRGBA{R: 0xeb, G: 0xeb, B: 0xeb, A: 0xd2})
+	expected.Set(3, 2, color.NRGBA{R: 0xf4, G: 0xf4, B: 0xf4, A: 0xe5})
+	expected.Set(3, 3, color.NRGBA{R: 0xeb, G: 0xeb, B: 0xeb, A: 0xd2})
+	expected.Set(3, 4, color.NRGBA{R: 0x9b, G: 0x9b, B: 0x9b, A: 0x83})
 
-	expected.Set(4, 0, color.NRGBA{R: 0x2d, G: 0x2d, B: 0x2d, A: 0x2d})
-	expected.Set(4, 1, color.NRGBA{R: 0x5f, G: 0x5f, B: 0x5f, A: 0x5f})
-	expected.Set(4, 2, color.NRGBA{R: 0x6a, G: 0x6a, B: 0x6a, A: 0x6a})
-	expected.Set(4, 3, color.NRGBA{R: 0x5f, G: 0x5f, B: 0x5f, A: 0x5f})
-	expected.Set(4, 4, color.NRGBA{R: 0x2d, G: 0x2d, B: 0x2d, A: 0x2d})
+	expected.Set(4, 0, color.NRGBA{R: 0x6c, G: 0x6c, B: 0x6c, A: 0x3d})
+	expected.Set(4, 1, color.NRGBA{R: 0x8e, G: 0x8e, B: 0x8e, A: 0x6d})
+	expected.Set(4, 2, color.NRGBA{R: 0x97, G: 0x97, B: 0x97, A: 0x7b})
+	expected.Set(4, 3, color.NRGBA{R: 0x8e, G: 0x8e, B: 0x8e, A: 0x6d})
+	expected.Set(4, 4, color.NRGBA{R: 0x6c, G: 0x6c, B: 0x6c, A: 0x3d})
 
 	result := Antialias(img, Soft)
 
@@ -237,6 +237,145 @@ func TestFivePixelSoftAntiAlias(t *testing.T) {
 	}
 }
 
+func TestBoxAntiAlias(t *testing.T) {
+	img := makeSinglePixelImage()
+	expected := image.NewNRGBA(image.Rect(0, 0, 3, 3))
+
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			expected.Set(x, y, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x1c})
+		}
+	}
+
+	kernel, err := Box(3)
+	if err != nil {
+		t.Fatalf("error creating box kernel (%v)", err)
+	}
+
+	result := Antialias(img, kernel)
+
+	if !reflect.DeepEqual(result, expected) {
+		diff(result, expected, t)
+	}
+}
+
+func TestGaussianAntiAlias(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+	expected := image.NewNRGBA(image.Rect(0, 0, 16, 16))
+
+	for x := 0; x < 16; x++ {
+		for y := 0; y < 16; y++ {
+			img.Set(x, y, color.NRGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+			expected.Set(x, y, color.NRGBA{R: 0x80, G: 0x40, B: 0x20, A: 0xff})
+		}
+	}
+
+	kernel, err := Gaussian(1.5)
+	if err != nil {
+		t.Fatalf("error creating Gaussian kernel (%v)", err)
+	}
+
+	if len(kernel.horizontal) != 11 || len(kernel.vertical) != 11 {
+		t.Errorf("incorrect Gaussian kernel size - expected:%v, got:%vx%v", 11, len(kernel.horizontal), len(kernel.vertical))
+	}
+
+	for i := 0; i < 5; i++ {
+		if kernel.horizontal[i] != kernel.horizontal[10-i] || kernel.horizontal[i] >= kernel.horizontal[i+1] {
+			t.Errorf("invalid Gaussian kernel weights %v", kernel.horizontal)
+			break
+		}
+	}
+
+	result := Antialias(img, kernel)
+
+	if !reflect.DeepEqual(result, expected) {
+		diff(result, expected, t)
+	}
+}
+
+func TestPremultipliedAlpha(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 3, 3))
+	img.Set(1, 1, color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff})
+
+	result := Antialias(img, Soft)
+
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if c := result.NRGBAAt(x, y); c.R != 0xff || c.G != 0x00 || c.B != 0x00 {
+				t.Errorf("incorrect colour at (%v,%v) - expected:%v, got:%v", x, y, "#ff0000", c)
+			}
+		}
+	}
+}
+
+func TestMirrorEdge(t *testing.T) {
+	img := makeFivePixelImage()
+
+	clamped := Antialias(img, Vertical)
+	mirrored := Antialias(img, Vertical.WithEdge(Mirror))
+
+	if expected, c := (color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x30}), clamped.NRGBAAt(0, 0); c != expected {
+		t.Errorf("incorrect clamped edge pixel - expected:%v, got:%v", expected, c)
+	}
+
+	if expected, c := (color.NRGBA{R: 0x50, G: 0x50, B: 0x50, A: 0x40}), mirrored.NRGBAAt(0, 0); c != expected {
+		t.Errorf("incorrect mirrored edge pixel - expected:%v, got:%v", expected, c)
+	}
+
+	if expected, c := clamped.NRGBAAt(2, 2), mirrored.NRGBAAt(2, 2); c != expected {
+		t.Errorf("incorrect mirrored interior pixel - expected:%v, got:%v", expected, c)
+	}
+}
+
+func TestEdgeResolve(t *testing.T) {
+	tests := []struct {
+		edge     Edge
+		v        int
+		expected int
+	}{
+		{Clamp, -2, 0},
+		{Clamp, 2, 2},
+		{Clamp, 6, 4},
+		{Mirror, -1, 1},
+		{Mirror, -2, 2},
+		{Mirror, 5, 3},
+		{Mirror, 6, 2},
+		{Mirror, 9, 1},
+	}
+
+	for _, test := range tests {
+		if v := test.edge.resolve(test.v, 5); v != test.expected {
+			t.Errorf("incorrect %v edge coordinate for %v - expected:%v, got:%v", test.edge, test.v, test.expected, v)
+		}
+	}
+}
+
+func TestInvalidKernels(t *testing.T) {
+	if _, err := NewKernel([][]float64{{1, 1}, {1, 1}}); err == nil {
+		t.Errorf("expected error for even sized kernel")
+	}
+
+	if _, err := NewKernel([][]float64{{1, 1, 1}, {1, 1}, {1, 1, 1}}); err == nil {
+		t.Errorf("expected error for non-square kernel")
+	}
+
+	if _, err := NewKernel([][]float64{{0, -1, 0}, {-1, 4, -1}, {0, -1, 0}}); err == nil {
+		t.Errorf("expected error for zero sum kernel")
+	}
+
+	if _, err := NewSeparable([]float64{1, 2, 1}, []float64{1, 1}); err == nil {
+		t.Errorf("expected error for even sized separable kernel")
+	}
+
+	if _, err := Gaussian(0); err == nil {
+		t.Errorf("expected error for zero Gaussian sigma")
+	}
+
+	if _, err := Box(2); err == nil {
+		t.Errorf("expected error for even box size")
+	}
+}
+
 func makeSinglePixelImage() *image.NRGBA {
 	img := image.NewNRGBA(image.Rect(0, 0, 3, 3))
 
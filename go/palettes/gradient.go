@@ -0,0 +1,271 @@
+package palettes
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"sort"
+)
+
+// Stop is a gradient colour stop, with the offset in the range [0,1].
+type Stop struct {
+	Offset float64
+	Colour color.NRGBA
+}
+
+// AlphaStop is an alpha ramp stop, with the offset and alpha in the range [0,1].
+type AlphaStop struct {
+	Offset float64
+	Alpha  float64
+}
+
+// Interpolation is the colour space used to interpolate between gradient stops.
+type Interpolation int
+
+const (
+	SRGB Interpolation = iota
+	LinearRGB
+	OKLab
+	OKLCH
+)
+
+const GRADIENT_SIZE = 256
+
+func ParseInterpolation(s string) (Interpolation, error) {
+	switch s {
+	case "", "srgb":
+		return SRGB, nil
+
+	case "linear":
+		return LinearRGB, nil
+
+	case "oklab":
+		return OKLab, nil
+
+	case "oklch":
+		return OKLCH, nil
+
+	default:
+		return SRGB, fmt.Errorf("invalid interpolation (%v)", s)
+	}
+}
+
+func (i Interpolation) String() string {
+	switch i {
+	case LinearRGB:
+		return "linear"
+
+	case OKLab:
+		return "oklab"
+
+	case OKLCH:
+		return "oklch"
+
+	default:
+		return "srgb"
+	}
+}
+
+// Builder generates an N-step palette from gradient colour stops, interpolated in the Interpolation
+// colour space. The (optional) alpha ramp is interpolated linearly between the alpha stops and
+// applied as a multiplier to the alpha of the interpolated colours.
+type Builder struct {
+	Stops         []Stop
+	Alpha         []AlphaStop
+	Interpolation Interpolation
+	Size          int
+}
+
+// NewGradient returns a palette with 'size' colours interpolated between the gradient stops. Colours
+// before the first stop and after the last stop are the colours of the first and last stops.
+func NewGradient(name string, stops []Stop, interpolation Interpolation, size int) (Palette, error) {
+	builder := Builder{
+		Stops:         stops,
+		Interpolation: interpolation,
+		Size:          size,
+	}
+
+	return builder.Build(name)
+}
+
+func (b Builder) Build(name string) (Palette, error) {
+	if len(b.Stops) == 0 {
+		return Palette{}, fmt.Errorf("invalid gradient - no colour stops")
+	}
+
+	if b.Size < 2 {
+		return Palette{}, fmt.Errorf("invalid gradient size (%v)", b.Size)
+	}
+
+	for _, stop := range b.Stops {
+		if !valid(stop.Offset) {
+			return Palette{}, fmt.Errorf("invalid gradient stop offset (%v)", stop.Offset)
+		}
+	}
+
+	for _, stop := range b.Alpha {
+		if !valid(stop.Offset) {
+			return Palette{}, fmt.Errorf("invalid alpha stop offset (%v)", stop.Offset)
+		} else if !valid(stop.Alpha) {
+			return Palette{}, fmt.Errorf("invalid alpha stop alpha (%v)", stop.Alpha)
+		}
+	}
+
+	stops := append([]Stop{}, b.Stops...)
+	sort.SliceStable(stops, func(i, j int) bool {
+		return stops[i].Offset < stops[j].Offset
+	})
+
+	alpha := append([]AlphaStop{}, b.Alpha...)
+	sort.SliceStable(alpha, func(i, j int) bool {
+		return alpha[i].Offset < alpha[j].Offset
+	})
+
+	colours := make([]color.NRGBA, b.Size)
+	for i := range colours {
+		t := float64(i) / float64(b.Size-1)
+		colour := interpolate(stops, t, b.Interpolation)
+
+		if len(alpha) > 0 {
+			colour.A = clamp(float64(colour.A) * ramp(alpha, t))
+		}
+
+		colours[i] = colour
+	}
+
+	return NewPalette(name, colours), nil
+}
+
+// Interpolate returns the colour at t in [0,1] of a gradient through evenly spaced colours,
+// interpolated in the sRGB colour space.
+func Interpolate(colours []color.NRGBA, t float64) color.NRGBA {
+	N := len(colours) - 1
+	if N < 0 {
+		return color.NRGBA{}
+	}
+
+	t = math.Max(0, math.Min(1, t))
+	i := int(math.Floor(t * float64(N)))
+	if i >= N {
+		return colours[N]
+	}
+
+	return Lerp(colours[i], colours[i+1], t*float64(N)-float64(i))
+}
+
+// Lerp interpolates linearly between two colours in the sRGB colour space.
+func Lerp(p, q color.NRGBA, t float64) color.NRGBA {
+	return mix(p, q, t, SRGB)
+}
+
+func interpolate(stops []Stop, t float64, interpolation Interpolation) color.NRGBA {
+	if t <= stops[0].Offset {
+		return stops[0].Colour
+	}
+
+	for i := 1; i < len(stops); i++ {
+		p := stops[i-1]
+		q := stops[i]
+
+		if t <= q.Offset {
+			if q.Offset == p.Offset {
+				return q.Colour
+			}
+
+			return mix(p.Colour, q.Colour, (t-p.Offset)/(q.Offset-p.Offset), interpolation)
+		}
+	}
+
+	return stops[len(stops)-1].Colour
+}
+
+func ramp(stops []AlphaStop, t float64) float64 {
+	if t <= stops[0].Offset {
+		return stops[0].Alpha
+	}
+
+	for i := 1; i < len(stops); i++ {
+		p := stops[i-1]
+		q := stops[i]
+
+		if t <= q.Offset {
+			if q.Offset == p.Offset {
+				return q.Alpha
+			}
+
+			f := (t - p.Offset) / (q.Offset - p.Offset)
+
+			return (1-f)*p.Alpha + f*q.Alpha
+		}
+	}
+
+	return stops[len(stops)-1].Alpha
+}
+
+func mix(p, q color.NRGBA, t float64, interpolation Interpolation) color.NRGBA {
+	lerp := func(u, v float64) float64 {
+		return (1-t)*u + t*v
+	}
+
+	alpha := clamp(lerp(float64(p.A), float64(q.A)))
+
+	switch interpolation {
+	case LinearRGB:
+		u := []float64{linear(p.R), linear(p.G), linear(p.B)}
+		v := []float64{linear(q.R), linear(q.G), linear(q.B)}
+
+		return color.NRGBA{
+			R: srgb(lerp(u[0], v[0])),
+			G: srgb(lerp(u[1], v[1])),
+			B: srgb(lerp(u[2], v[2])),
+			A: alpha,
+		}
+
+	case OKLab:
+		L0, a0, b0 := oklab(p)
+		L1, a1, b1 := oklab(q)
+		c := fromOKLab(lerp(L0, L1), lerp(a0, a1), lerp(b0, b1))
+		c.A = alpha
+
+		return c
+
+	case OKLCH:
+		L0, C0, h0 := oklch(p)
+		L1, C1, h1 := oklch(q)
+
+		// ... achromatic colours have no meaningful hue
+		if C0 < 1e-4 {
+			h0 = h1
+		} else if C1 < 1e-4 {
+			h1 = h0
+		}
+
+		// ... shortest arc
+		if dh := h1 - h0; dh > math.Pi {
+			h1 -= 2 * math.Pi
+		} else if dh < -math.Pi {
+			h1 += 2 * math.Pi
+		}
+
+		c := fromOKLCH(lerp(L0, L1), lerp(C0, C1), lerp(h0, h1))
+		c.A = alpha
+
+		return c
+
+	default:
+		return color.NRGBA{
+			R: clamp(lerp(float64(p.R), float64(q.R))),
+			G: clamp(lerp(float64(p.G), float64(q.G))),
+			B: clamp(lerp(float64(p.B), float64(q.B))),
+			A: alpha,
+		}
+	}
+}
+
+func valid(v float64) bool {
+	return v >= 0 && v <= 1
+}
+
+func clamp(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
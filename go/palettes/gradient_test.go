@@ -0,0 +1,230 @@
+package palettes
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGradient(t *testing.T) {
+	stops := []Stop{
+		{Offset: 1.0, Colour: color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}},
+		{Offset: 0.0, Colour: color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x00}},
+	}
+
+	tests := []struct {
+		interpolation Interpolation
+		expected      []color.NRGBA
+	}{
+		{
+			SRGB,
+			[]color.NRGBA{
+				{R: 0x00, G: 0x00, B: 0x00, A: 0x00},
+				{R: 0x40, G: 0x40, B: 0x40, A: 0x40},
+				{R: 0x80, G: 0x80, B: 0x80, A: 0x80},
+				{R: 0xbf, G: 0xbf, B: 0xbf, A: 0xbf},
+				{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			},
+		},
+		{
+			LinearRGB,
+			[]color.NRGBA{
+				{R: 0x00, G: 0x00, B: 0x00, A: 0x00},
+				{R: 0x89, G: 0x89, B: 0x89, A: 0x40},
+				{R: 0xbc, G: 0xbc, B: 0xbc, A: 0x80},
+				{R: 0xe1, G: 0xe1, B: 0xe1, A: 0xbf},
+				{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		palette, err := NewGradient("test", stops, test.interpolation, 5)
+		if err != nil {
+			t.Fatalf("error creating %v gradient (%v)", test.interpolation, err)
+		}
+
+		colours := palette.Realize()
+		if len(colours) != len(test.expected) {
+			t.Fatalf("incorrect %v gradient size - expected:%v, got:%v", test.interpolation, len(test.expected), len(colours))
+		}
+
+		for i, c := range test.expected {
+			if colours[i] != c {
+				t.Errorf("incorrect %v gradient colour %v - expected:%v, got:%v", test.interpolation, i, c, colours[i])
+			}
+		}
+	}
+}
+
+func TestGradientStops(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+
+	stops := []Stop{
+		{Offset: 0.25, Colour: red},
+		{Offset: 0.75, Colour: blue},
+	}
+
+	palette, err := NewGradient("test", stops, SRGB, 9)
+	if err != nil {
+		t.Fatalf("error creating gradient (%v)", err)
+	}
+
+	colours := palette.Realize()
+	expected := map[int]color.NRGBA{
+		0: red,
+		2: red,
+		4: {R: 0x80, G: 0x00, B: 0x80, A: 0xff},
+		6: blue,
+		8: blue,
+	}
+
+	for i, c := range expected {
+		if colours[i] != c {
+			t.Errorf("incorrect gradient colour %v - expected:%v, got:%v", i, c, colours[i])
+		}
+	}
+}
+
+func TestInvalidGradient(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+
+	if _, err := NewGradient("test", nil, SRGB, 256); err == nil {
+		t.Errorf("expected error for gradient without stops")
+	}
+
+	if _, err := NewGradient("test", []Stop{{Offset: 1.5, Colour: red}}, SRGB, 256); err == nil {
+		t.Errorf("expected error for invalid gradient stop offset")
+	}
+
+	if _, err := NewGradient("test", []Stop{{Offset: 0.5, Colour: red}}, SRGB, 1); err == nil {
+		t.Errorf("expected error for invalid gradient size")
+	}
+
+	if _, err := ParseInterpolation("hsv"); err == nil {
+		t.Errorf("expected error for invalid interpolation")
+	}
+}
+
+func TestOKLab(t *testing.T) {
+	colours := []color.NRGBA{
+		{R: 0x00, G: 0x00, B: 0x00, A: 0xff},
+		{R: 0xff, G: 0xff, B: 0xff, A: 0xff},
+		{R: 0xff, G: 0x00, B: 0x00, A: 0xff},
+		{R: 0x21, G: 0x90, B: 0x8c, A: 0xff},
+		{R: 0xc8, G: 0xe8, B: 0xff, A: 0xff},
+	}
+
+	for _, c := range colours {
+		if v := fromOKLab(oklab(c)); v != c {
+			t.Errorf("incorrect OKLab round trip - expected:%v, got:%v", c, v)
+		}
+
+		if v := fromOKLCH(oklch(c)); v != c {
+			t.Errorf("incorrect OKLCH round trip - expected:%v, got:%v", c, v)
+		}
+	}
+}
+
+func TestPerceptualGradient(t *testing.T) {
+	black := color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+
+	// ... OKLab lightness is linear between black and white
+	palette, err := NewGradient("test", []Stop{{Offset: 0, Colour: black}, {Offset: 1, Colour: white}}, OKLab, 3)
+	if err != nil {
+		t.Fatalf("error creating OKLab gradient (%v)", err)
+	} else if expected, c := (color.NRGBA{R: 0x63, G: 0x63, B: 0x63, A: 0xff}), palette.Realize()[1]; c != expected {
+		t.Errorf("incorrect OKLab gradient colour - expected:%v, got:%v", expected, c)
+	}
+
+	// ... OKLCH hue interpolates along the shortest arc i.e. red to blue via magenta
+	palette, err = NewGradient("test", []Stop{{Offset: 0, Colour: red}, {Offset: 1, Colour: blue}}, OKLCH, 3)
+	if err != nil {
+		t.Fatalf("error creating OKLCH gradient (%v)", err)
+	} else if c := palette.Realize()[1]; c.R < 0x80 || c.B < 0x80 || c.G > 0x40 {
+		t.Errorf("incorrect OKLCH gradient colour - expected:magenta, got:%v", c)
+	}
+}
+
+func TestAlphaRamp(t *testing.T) {
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	builder := Builder{
+		Stops:         []Stop{{Offset: 0, Colour: white}},
+		Alpha:         []AlphaStop{{Offset: 0.0, Alpha: 0.0}, {Offset: 0.5, Alpha: 1.0}},
+		Interpolation: OKLab,
+		Size:          5,
+	}
+
+	palette, err := builder.Build("test")
+	if err != nil {
+		t.Fatalf("error building palette (%v)", err)
+	}
+
+	expected := []uint8{0x00, 0x80, 0xff, 0xff, 0xff}
+	for i, c := range palette.Realize() {
+		if c.R != 0xff || c.G != 0xff || c.B != 0xff || c.A != expected[i] {
+			t.Errorf("incorrect alpha ramp colour %v - expected:%v, got:%v", i, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: expected[i]}, c)
+		}
+	}
+
+	builder.Alpha = []AlphaStop{{Offset: 0.5, Alpha: 1.5}}
+	if _, err := builder.Build("test"); err == nil {
+		t.Errorf("expected error for invalid alpha stop")
+	}
+}
+
+func TestScientificPalettes(t *testing.T) {
+	tests := []struct {
+		palette Palette
+		first   color.NRGBA
+		last    color.NRGBA
+	}{
+		{Viridis, color.NRGBA{R: 0x44, G: 0x01, B: 0x54, A: 0xff}, color.NRGBA{R: 0xfd, G: 0xe7, B: 0x25, A: 0xff}},
+		{Magma, color.NRGBA{R: 0x00, G: 0x00, B: 0x04, A: 0xff}, color.NRGBA{R: 0xfc, G: 0xfd, B: 0xbf, A: 0xff}},
+		{Inferno, color.NRGBA{R: 0x00, G: 0x00, B: 0x04, A: 0xff}, color.NRGBA{R: 0xfc, G: 0xff, B: 0xa4, A: 0xff}},
+		{Cividis, color.NRGBA{R: 0x00, G: 0x20, B: 0x4d, A: 0xff}, color.NRGBA{R: 0xff, G: 0xea, B: 0x46, A: 0xff}},
+	}
+
+	for _, test := range tests {
+		colours := test.palette.Realize()
+
+		if len(colours) != GRADIENT_SIZE {
+			t.Errorf("incorrect %v palette size - expected:%v, got:%v", test.palette, GRADIENT_SIZE, len(colours))
+		} else if colours[0] != test.first || colours[GRADIENT_SIZE-1] != test.last {
+			t.Errorf("incorrect %v palette - expected:%v..%v, got:%v..%v", test.palette, test.first, test.last, colours[0], colours[GRADIENT_SIZE-1])
+		}
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	green := color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0x00}
+
+	tests := []struct {
+		t        float64
+		expected color.NRGBA
+	}{
+		{-1.0, red},
+		{0.0, red},
+		{0.25, color.NRGBA{R: 0x80, G: 0x80, B: 0x00, A: 0xff}},
+		{0.5, green},
+		{0.75, color.NRGBA{R: 0x00, G: 0x80, B: 0x80, A: 0x80}},
+		{1.0, blue},
+		{2.0, blue},
+	}
+
+	for _, test := range tests {
+		if c := Interpolate([]color.NRGBA{red, green, blue}, test.t); c != test.expected {
+			t.Errorf("incorrect colour at %v - expected:%v, got:%v", test.t, test.expected, c)
+		}
+	}
+
+	if c := Interpolate(nil, 0.5); c != (color.NRGBA{}) {
+		t.Errorf("incorrect colour for empty gradient - expected:%v, got:%v", color.NRGBA{}, c)
+	}
+}
@@ -0,0 +1,69 @@
+package palettes
+
+import (
+	"image/color"
+	"math"
+)
+
+// Colour space conversions for the OKLab perceptual colour space (https://bottosson.github.io/posts/oklab).
+
+// linear converts an sRGB colour component to linear RGB.
+func linear(v uint8) float64 {
+	c := float64(v) / 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+// srgb converts a linear RGB colour component to sRGB.
+func srgb(v float64) uint8 {
+	if v <= 0.0031308 {
+		return clamp(255 * 12.92 * v)
+	}
+
+	return clamp(255 * (1.055*math.Pow(v, 1/2.4) - 0.055))
+}
+
+func oklab(c color.NRGBA) (float64, float64, float64) {
+	r := linear(c.R)
+	g := linear(c.G)
+	b := linear(c.B)
+
+	l := math.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	m := math.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	s := math.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	return 0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s
+}
+
+// fromOKLab converts an OKLab colour to an (opaque) sRGB colour, clipping out of gamut colours.
+func fromOKLab(L, a, b float64) color.NRGBA {
+	l := L + 0.3963377774*a + 0.2158037573*b
+	m := L - 0.1055613458*a - 0.0638541728*b
+	s := L - 0.0894841775*a - 1.2914855480*b
+
+	l = l * l * l
+	m = m * m * m
+	s = s * s * s
+
+	return color.NRGBA{
+		R: srgb(+4.0767416621*l - 3.3077115913*m + 0.2309699292*s),
+		G: srgb(-1.2684380046*l + 2.6097574011*m - 0.3413193965*s),
+		B: srgb(-0.0041960863*l - 0.7034186147*m + 1.7076147010*s),
+		A: 0xff,
+	}
+}
+
+func oklch(c color.NRGBA) (float64, float64, float64) {
+	L, a, b := oklab(c)
+
+	return L, math.Hypot(a, b), math.Atan2(b, a)
+}
+
+func fromOKLCH(L, C, h float64) color.NRGBA {
+	return fromOKLab(L, C*math.Cos(h), C*math.Sin(h))
+}
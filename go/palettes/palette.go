@@ -41,6 +41,13 @@ type Palette struct {
 	colours []color.NRGBA
 }
 
+func NewPalette(name string, colours []color.NRGBA) Palette {
+	return Palette{
+		name:    name,
+		colours: colours,
+	}
+}
+
 func PaletteFromPng(name string, png image.Image) (*Palette, error) {
 	bounds := png.Bounds()
 	if bounds.Empty() {
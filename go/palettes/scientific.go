@@ -0,0 +1,42 @@
+package palettes
+
+import (
+	"fmt"
+	"image/color"
+)
+
+// Approximations of the matplotlib 'perceptually uniform' scientific colour maps, interpolated in OKLab
+// between 11 (10 for cividis) evenly spaced samples of each colour map. They are NOT the published 256
+// entry tables and are not guaranteed to be perceptually uniform.
+
+var Viridis = mustGradient("viridis",
+	0x440154, 0x482576, 0x414487, 0x35608d, 0x2a788e, 0x21908c,
+	0x22a884, 0x43bf71, 0x7ad151, 0xbbdf27, 0xfde725)
+
+var Magma = mustGradient("magma",
+	0x000004, 0x140e37, 0x3b0f70, 0x641a80, 0x8c2981, 0xb63679,
+	0xde4968, 0xf66e5c, 0xfe9f6d, 0xfecf92, 0xfcfdbf)
+
+var Inferno = mustGradient("inferno",
+	0x000004, 0x160b39, 0x420a68, 0x6a176e, 0x932667, 0xbc3754,
+	0xdd513a, 0xf3761b, 0xfca50a, 0xf6d746, 0xfcffa4)
+
+var Cividis = mustGradient("cividis",
+	0x00204d, 0x00336f, 0x39486b, 0x575c6d, 0x707173, 0x8a8779,
+	0xa69d75, 0xc4b56c, 0xe4cf5b, 0xffea46)
+
+func mustGradient(name string, rgb ...uint32) Palette {
+	stops := make([]Stop, len(rgb))
+	for i, v := range rgb {
+		stops[i] = Stop{
+			Offset: float64(i) / float64(len(rgb)-1),
+			Colour: color.NRGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff},
+		}
+	}
+
+	if palette, err := NewGradient(name, stops, OKLab, GRADIENT_SIZE); err != nil {
+		panic(fmt.Errorf("invalid palette (%v)", err))
+	} else {
+		return palette
+	}
+}
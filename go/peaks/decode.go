@@ -0,0 +1,163 @@
+package peaks
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MAX_CHANNELS and MAX_LENGTH limit the number of channels and peaks accepted from a peaks file
+// header, so that a corrupt file can't exhaust memory.
+const (
+	MAX_CHANNELS = 24
+	MAX_LENGTH   = 1 << 24
+)
+
+// Decode reads peaks in either the audiowaveform JSON or binary format. Multi-channel peaks are
+// mixed down to a single channel by taking the min/max across all the channels.
+func Decode(r io.Reader) (*Peaks, error) {
+	b := bufio.NewReader(r)
+
+	for {
+		if c, err := b.Peek(1); err != nil {
+			return nil, err
+		} else if bytes.ContainsAny(c, " \t\r\n") {
+			b.ReadByte()
+		} else if c[0] == '{' {
+			return DecodeJSON(b)
+		} else {
+			return DecodeDat(b)
+		}
+	}
+}
+
+// DecodeJSON reads peaks in the audiowaveform JSON format (versions 1 and 2).
+func DecodeJSON(r io.Reader) (*Peaks, error) {
+	var s serializable
+
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+
+	if s.Version != 1 && s.Version != 2 {
+		return nil, fmt.Errorf("unsupported peaks version (%v)", s.Version)
+	} else if s.Version == 1 || s.Channels == 0 {
+		s.Channels = 1
+	}
+
+	if s.Channels < 1 || s.Channels > MAX_CHANNELS {
+		return nil, fmt.Errorf("invalid number of channels (%v)", s.Channels)
+	} else if s.Length < 0 || s.Length > MAX_LENGTH {
+		return nil, fmt.Errorf("invalid peaks length (%v)", s.Length)
+	} else if s.Bits != 8 && s.Bits != 16 {
+		return nil, fmt.Errorf("invalid peak resolution (%v)", s.Bits)
+	} else if s.SampleRate <= 0 || s.SamplesPerPixel <= 0 {
+		return nil, fmt.Errorf("invalid peaks sample rate/samples per pixel (%v/%v)", s.SampleRate, s.SamplesPerPixel)
+	} else if s.Length > len(s.Data)/(2*s.Channels) {
+		return nil, fmt.Errorf("invalid peaks data - expected %v values, got %v", 2*s.Channels*s.Length, len(s.Data))
+	}
+
+	peaks := Peaks{
+		SampleRate:      s.SampleRate,
+		SamplesPerPixel: s.SamplesPerPixel,
+		Bits:            s.Bits,
+		Min:             make([]int16, 0, s.Length),
+		Max:             make([]int16, 0, s.Length),
+	}
+
+	for i := 0; i < s.Length; i++ {
+		peaks.mixdown(s.Data[2*s.Channels*i : 2*s.Channels*(i+1)])
+	}
+
+	return &peaks, nil
+}
+
+// DecodeDat reads peaks in the audiowaveform binary format (versions 1 and 2).
+func DecodeDat(r io.Reader) (*Peaks, error) {
+	header := struct {
+		Version         int32
+		Flags           uint32
+		SampleRate      int32
+		SamplesPerPixel int32
+		Length          uint32
+	}{}
+
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, err
+	}
+
+	channels := int32(1)
+	if header.Version == 2 {
+		if err := binary.Read(r, binary.LittleEndian, &channels); err != nil {
+			return nil, err
+		}
+	} else if header.Version != 1 {
+		return nil, fmt.Errorf("unsupported peaks version (%v)", header.Version)
+	}
+
+	if header.SampleRate <= 0 || header.SamplesPerPixel <= 0 {
+		return nil, fmt.Errorf("invalid peaks sample rate/samples per pixel (%v/%v)", header.SampleRate, header.SamplesPerPixel)
+	} else if channels < 1 || channels > MAX_CHANNELS {
+		return nil, fmt.Errorf("invalid number of channels (%v)", channels)
+	} else if header.Length > MAX_LENGTH {
+		return nil, fmt.Errorf("invalid peaks length (%v)", header.Length)
+	}
+
+	// ... read the peaks a pixel at a time, so that a header length that overstates the data fails
+	//     on the truncated stream rather than allocating the full length up front
+	peaks := Peaks{
+		SampleRate:      int(header.SampleRate),
+		SamplesPerPixel: int(header.SamplesPerPixel),
+		Bits:            16,
+		Min:             []int16{},
+		Max:             []int16{},
+	}
+
+	data := make([]int16, 2*channels)
+	v := make([]int8, 2*channels)
+
+	if header.Flags&FLAG_8BIT != 0 {
+		peaks.Bits = 8
+	}
+
+	for i := uint32(0); i < header.Length; i++ {
+		if peaks.Bits == 8 {
+			if err := binary.Read(r, binary.LittleEndian, v); err != nil {
+				return nil, err
+			}
+
+			for j := range v {
+				data[j] = int16(v[j])
+			}
+		} else if err := binary.Read(r, binary.LittleEndian, data); err != nil {
+			return nil, err
+		}
+
+		peaks.mixdown(data)
+	}
+
+	return &peaks, nil
+}
+
+// mixdown appends the min/max across all the channels of the interleaved min/max pairs of a
+// single pixel.
+func (p *Peaks) mixdown(data []int16) {
+	min := data[0]
+	max := data[1]
+
+	for ch := 2; ch < len(data); ch += 2 {
+		if data[ch] < min {
+			min = data[ch]
+		}
+
+		if data[ch+1] > max {
+			max = data[ch+1]
+		}
+	}
+
+	p.Min = append(p.Min, min)
+	p.Max = append(p.Max, max)
+}
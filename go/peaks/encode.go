@@ -0,0 +1,89 @@
+package peaks
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	DAT_VERSION  int32  = 1
+	JSON_VERSION int    = 2
+	FLAG_8BIT    uint32 = 0x00000001
+)
+
+type serializable struct {
+	Version         int     `json:"version"`
+	Channels        int     `json:"channels"`
+	SampleRate      int     `json:"sample_rate"`
+	SamplesPerPixel int     `json:"samples_per_pixel"`
+	Bits            int     `json:"bits"`
+	Length          int     `json:"length"`
+	Data            []int16 `json:"data"`
+	RMS             []int16 `json:"rms,omitempty"`
+}
+
+// EncodeJSON writes the peaks in the audiowaveform JSON format. RMS values (if any) are included as
+// an additional 'rms' field, which is ignored by peaks.js.
+func (p Peaks) EncodeJSON(w io.Writer) error {
+	data := make([]int16, 2*len(p.Min))
+	for i := range p.Min {
+		data[2*i] = p.Min[i]
+		data[2*i+1] = p.Max[i]
+	}
+
+	return json.NewEncoder(w).Encode(serializable{
+		Version:         JSON_VERSION,
+		Channels:        1,
+		SampleRate:      p.SampleRate,
+		SamplesPerPixel: p.SamplesPerPixel,
+		Bits:            p.Bits,
+		Length:          p.Length(),
+		Data:            data,
+		RMS:             p.RMS,
+	})
+}
+
+// EncodeDat writes the peaks in the (version 1) audiowaveform binary format. The binary format has
+// no provision for RMS values and they are not included.
+func (p Peaks) EncodeDat(w io.Writer) error {
+	flags := uint32(0)
+	if p.Bits == 8 {
+		flags |= FLAG_8BIT
+	} else if p.Bits != 16 {
+		return fmt.Errorf("invalid peak resolution (%v)", p.Bits)
+	}
+
+	header := []any{
+		DAT_VERSION,
+		flags,
+		int32(p.SampleRate),
+		int32(p.SamplesPerPixel),
+		uint32(p.Length()),
+	}
+
+	for _, v := range header {
+		if err := binary.Write(w, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+
+	if p.Bits == 8 {
+		data := make([]int8, 2*len(p.Min))
+		for i := range p.Min {
+			data[2*i] = int8(p.Min[i])
+			data[2*i+1] = int8(p.Max[i])
+		}
+
+		return binary.Write(w, binary.LittleEndian, data)
+	}
+
+	data := make([]int16, 2*len(p.Min))
+	for i := range p.Min {
+		data[2*i] = p.Min[i]
+		data[2*i+1] = p.Max[i]
+	}
+
+	return binary.Write(w, binary.LittleEndian, data)
+}
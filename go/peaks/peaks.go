@@ -0,0 +1,143 @@
+// Package peaks computes the per-pixel min/max peak data used by waveform viewers such as peaks.js,
+// encoded in the audiowaveform binary (.dat) and JSON formats.
+package peaks
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+type Peaks struct {
+	SampleRate      int
+	SamplesPerPixel int
+	Bits            int
+	Min             []int16
+	Max             []int16
+	RMS             []int16
+}
+
+// Compute buckets the samples into groups of spp samples and returns the min/max (and optionally
+// RMS) of each bucket, scaled to the 8 or 16 bit signed integer range.
+func Compute(samples []float32, fs float64, spp int, bits int, rms bool) (*Peaks, error) {
+	if fs <= 0 {
+		return nil, fmt.Errorf("invalid sample rate (%v)", fs)
+	} else if spp < 1 {
+		return nil, fmt.Errorf("invalid samples per pixel (%v)", spp)
+	} else if bits != 8 && bits != 16 {
+		return nil, fmt.Errorf("invalid peak resolution (%v) - expected 8 or 16 bits", bits)
+	}
+
+	N := (len(samples) + spp - 1) / spp
+	peaks := Peaks{
+		SampleRate:      int(math.Round(fs)),
+		SamplesPerPixel: spp,
+		Bits:            bits,
+		Min:             make([]int16, N),
+		Max:             make([]int16, N),
+	}
+
+	if rms {
+		peaks.RMS = make([]int16, N)
+	}
+
+	for i := 0; i < N; i++ {
+		start := i * spp
+		end := start + spp
+		if end > len(samples) {
+			end = len(samples)
+		}
+
+		min := float32(math.MaxFloat32)
+		max := float32(-math.MaxFloat32)
+		sum := 0.0
+
+		for _, sample := range samples[start:end] {
+			if sample < min {
+				min = sample
+			}
+
+			if sample > max {
+				max = sample
+			}
+
+			sum += float64(sample) * float64(sample)
+		}
+
+		peaks.Min[i] = quantize(float64(min), bits)
+		peaks.Max[i] = quantize(float64(max), bits)
+
+		if rms {
+			peaks.RMS[i] = quantize(math.Sqrt(sum/float64(end-start)), bits)
+		}
+	}
+
+	return &peaks, nil
+}
+
+// Length returns the number of min/max pairs.
+func (p Peaks) Length() int {
+	return len(p.Min)
+}
+
+// quantize maps a sample in the range [-1.0,+1.0] to a signed 8 or 16 bit integer, using the same
+// conversion as audiowaveform i.e. an 8-bit value is the 16-bit value divided by 256.
+func quantize(v float64, bits int) int16 {
+	scale := math.Pow(2, float64(bits-1))
+	q := math.Floor(v * scale)
+
+	return int16(math.Max(-scale, math.Min(scale-1, q)))
+}
+
+// Duration returns the duration of the audio summarised by the peaks.
+func (p Peaks) Duration() time.Duration {
+	return time.Duration(float64(p.Length()*p.SamplesPerPixel) * float64(time.Second) / float64(p.SampleRate))
+}
+
+// Slice returns the peaks in the interval [from,to).
+func (p Peaks) Slice(from, to time.Duration) Peaks {
+	start := int(math.Floor(from.Seconds() * float64(p.SampleRate) / float64(p.SamplesPerPixel)))
+	end := int(math.Ceil(to.Seconds() * float64(p.SampleRate) / float64(p.SamplesPerPixel)))
+
+	if start < 0 {
+		start = 0
+	} else if start > p.Length() {
+		start = p.Length()
+	}
+
+	if end < start {
+		end = start
+	} else if end > p.Length() {
+		end = p.Length()
+	}
+
+	slice := p
+	slice.Min = p.Min[start:end]
+	slice.Max = p.Max[start:end]
+	if p.RMS != nil {
+		slice.RMS = p.RMS[start:end]
+	}
+
+	return slice
+}
+
+// Samples 'expands' the peaks into a sample stream with a min and max sample for each peak, suitable for
+// rendering with the existing renderers. The min/max pairs are repeated as required to generate at least
+// N samples so that a waveform rendered N/2 pixels wide has at least one min/max pair per pixel. Also
+// returns the effective sample rate of the sample stream.
+func (p Peaks) Samples(N int) ([]float32, float64) {
+	scale := float32(math.Pow(2, float64(p.Bits-1)))
+	repeat := 1
+	if p.Length() > 0 && N > 2*p.Length() {
+		repeat = (N + 2*p.Length() - 1) / (2 * p.Length())
+	}
+
+	samples := make([]float32, 0, 2*repeat*p.Length())
+	for i := range p.Min {
+		for j := 0; j < repeat; j++ {
+			samples = append(samples, float32(p.Min[i])/scale, float32(p.Max[i])/scale)
+		}
+	}
+
+	return samples, 2.0 * float64(repeat) * float64(p.SampleRate) / float64(p.SamplesPerPixel)
+}
@@ -0,0 +1,257 @@
+package peaks
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+var samples = []float32{0.0, 0.5, -0.5, 0.25, 1.0, -1.0, 0.125, -0.125, 0.0, 0.75}
+
+func TestCompute8Bit(t *testing.T) {
+	expected := Peaks{
+		SampleRate:      8000,
+		SamplesPerPixel: 4,
+		Bits:            8,
+		Min:             []int16{-64, -128, 0},
+		Max:             []int16{64, 127, 96},
+	}
+
+	peaks, err := Compute(samples, 8000, 4, 8, false)
+	if err != nil {
+		t.Fatalf("error computing peaks (%v)", err)
+	}
+
+	if !reflect.DeepEqual(*peaks, expected) {
+		t.Errorf("incorrect peaks\n   expected:%+v\n   got:     %+v", expected, *peaks)
+	}
+}
+
+func TestCompute16Bit(t *testing.T) {
+	expected := Peaks{
+		SampleRate:      8000,
+		SamplesPerPixel: 5,
+		Bits:            16,
+		Min:             []int16{-16384, -32768},
+		Max:             []int16{32767, 24576},
+		RMS:             []int16{18317, 18500},
+	}
+
+	peaks, err := Compute(samples, 8000, 5, 16, true)
+	if err != nil {
+		t.Fatalf("error computing peaks (%v)", err)
+	}
+
+	if !reflect.DeepEqual(*peaks, expected) {
+		t.Errorf("incorrect peaks\n   expected:%+v\n   got:     %+v", expected, *peaks)
+	}
+}
+
+func TestComputeWithInvalidArgs(t *testing.T) {
+	if _, err := Compute(samples, 8000, 0, 8, false); err == nil {
+		t.Errorf("expected error for invalid samples per pixel")
+	}
+
+	if _, err := Compute(samples, 8000, 4, 12, false); err == nil {
+		t.Errorf("expected error for invalid bits")
+	}
+}
+
+func TestEncodeDat(t *testing.T) {
+	expected := []byte{
+		0x01, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x40, 0x1f, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+		0x03, 0x00, 0x00, 0x00,
+		0xc0, 0x40, 0x80, 0x7f, 0x00, 0x60,
+	}
+
+	peaks, _ := Compute(samples, 8000, 4, 8, false)
+
+	var b bytes.Buffer
+	if err := peaks.EncodeDat(&b); err != nil {
+		t.Fatalf("error encoding peaks (%v)", err)
+	}
+
+	if !reflect.DeepEqual(b.Bytes(), expected) {
+		t.Errorf("incorrectly encoded peaks\n   expected:%v\n   got:     %v", expected, b.Bytes())
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	expected := `{"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":3,"data":[-64,64,-128,127,0,96]}` + "\n"
+
+	peaks, _ := Compute(samples, 8000, 4, 8, false)
+
+	var b bytes.Buffer
+	if err := peaks.EncodeJSON(&b); err != nil {
+		t.Fatalf("error encoding peaks (%v)", err)
+	}
+
+	if b.String() != expected {
+		t.Errorf("incorrectly encoded peaks\n   expected:%v\n   got:     %v", expected, b.String())
+	}
+}
+
+func TestDecodeDat(t *testing.T) {
+	expected, _ := Compute(samples, 8000, 4, 16, false)
+
+	var b bytes.Buffer
+	if err := expected.EncodeDat(&b); err != nil {
+		t.Fatalf("error encoding peaks (%v)", err)
+	}
+
+	if peaks, err := Decode(&b); err != nil {
+		t.Fatalf("error decoding peaks (%v)", err)
+	} else if !reflect.DeepEqual(peaks, expected) {
+		t.Errorf("incorrectly decoded peaks\n   expected:%+v\n   got:     %+v", expected, peaks)
+	}
+}
+
+func TestDecodeDatV2(t *testing.T) {
+	expected := Peaks{
+		SampleRate:      8000,
+		SamplesPerPixel: 4,
+		Bits:            8,
+		Min:             []int16{-64, -128},
+		Max:             []int16{96, 127},
+	}
+
+	dat := []byte{
+		0x02, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x00,
+		0x40, 0x1f, 0x00, 0x00,
+		0x04, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x00,
+		0xc0, 0x40, 0xe0, 0x60,
+		0x80, 0x10, 0xf0, 0x7f,
+	}
+
+	if peaks, err := Decode(bytes.NewReader(dat)); err != nil {
+		t.Fatalf("error decoding peaks (%v)", err)
+	} else if !reflect.DeepEqual(*peaks, expected) {
+		t.Errorf("incorrectly decoded peaks\n   expected:%+v\n   got:     %+v", expected, *peaks)
+	}
+}
+
+func TestDecodeDatWithInvalidHeader(t *testing.T) {
+	tests := map[string][]byte{
+		"truncated header": {
+			0x02, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0x40, 0x1f, 0x00, 0x00,
+		},
+		"zero channels": {
+			0x02, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0x40, 0x1f, 0x00, 0x00,
+			0x04, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+			0xc0, 0x40,
+		},
+		"too many channels": {
+			0x02, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0x40, 0x1f, 0x00, 0x00,
+			0x04, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0x7f,
+			0xc0, 0x40,
+		},
+		"huge length": {
+			0x02, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+			0x40, 0x1f, 0x00, 0x00,
+			0x04, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff,
+			0x18, 0x00, 0x00, 0x00,
+			0xc0, 0x40,
+		},
+		"truncated data": {
+			0x02, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0x40, 0x1f, 0x00, 0x00,
+			0x04, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x01,
+			0x02, 0x00, 0x00, 0x00,
+			0xc0, 0x40, 0xe0, 0x60,
+		},
+	}
+
+	for name, dat := range tests {
+		if _, err := Decode(bytes.NewReader(dat)); err == nil {
+			t.Errorf("expected error decoding peaks with %v", name)
+		}
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	expected := Peaks{
+		SampleRate:      8000,
+		SamplesPerPixel: 4,
+		Bits:            8,
+		Min:             []int16{-64, -128, 0},
+		Max:             []int16{64, 127, 96},
+	}
+
+	json := `  {"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":3,"data":[-64,64,-128,127,0,96]}`
+
+	if peaks, err := Decode(bytes.NewBufferString(json)); err != nil {
+		t.Fatalf("error decoding peaks (%v)", err)
+	} else if !reflect.DeepEqual(*peaks, expected) {
+		t.Errorf("incorrectly decoded peaks\n   expected:%+v\n   got:     %+v", expected, *peaks)
+	}
+}
+
+func TestDecodeJSONWithInvalidHeader(t *testing.T) {
+	tests := []string{
+		`{"version":2,"channels":-1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":1,"data":[-64,64]}`,
+		`{"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":-1,"data":[-64,64]}`,
+		`{"version":2,"channels":-1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":-1,"data":[]}`,
+		`{"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":2,"data":[-64,64]}`,
+		`{"version":3,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":1,"data":[-64,64]}`,
+		`{"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":12,"length":1,"data":[-64,64]}`,
+		`{"version":2,"channels":25,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":1,"data":[-64,64]}`,
+		`{"version":2,"channels":1,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":4611686018427387904,"data":[-64,64]}`,
+		`{"version":2,"channels":24,"sample_rate":8000,"samples_per_pixel":4,"bits":8,"length":16777216,"data":[-64,64]}`,
+	}
+
+	for _, json := range tests {
+		if _, err := Decode(bytes.NewBufferString(json)); err == nil {
+			t.Errorf("expected error decoding peaks with invalid header %v", json)
+		}
+	}
+}
+
+func TestSamples(t *testing.T) {
+	expected := []float32{-0.5, 0.5, -1.0, 0.9921875, 0.0, 0.75}
+
+	peaks, _ := Compute(samples, 8000, 4, 8, false)
+	samples, fs := peaks.Samples(0)
+
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("incorrect samples\n   expected:%v\n   got:     %v", expected, samples)
+	}
+
+	if fs != 4000 {
+		t.Errorf("incorrect sample rate - expected:%v, got:%v", 4000, fs)
+	}
+}
+
+func TestSamplesWithRepeat(t *testing.T) {
+	expected := []float32{-0.5, 0.5, -0.5, 0.5, -1.0, 0.9921875, -1.0, 0.9921875, 0.0, 0.75, 0.0, 0.75}
+
+	peaks, _ := Compute(samples, 8000, 4, 8, false)
+	samples, fs := peaks.Samples(10)
+
+	if !reflect.DeepEqual(samples, expected) {
+		t.Errorf("incorrect samples\n   expected:%v\n   got:     %v", expected, samples)
+	}
+
+	if fs != 8000 {
+		t.Errorf("incorrect sample rate - expected:%v, got:%v", 8000, fs)
+	}
+}
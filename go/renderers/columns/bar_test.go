@@ -0,0 +1,106 @@
+package columns
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+var red = color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+var blue = color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+
+func TestRenderWithAmplitudeColouring(t *testing.T) {
+	renderer := Columns{
+		BarWidth:  8,
+		BarGap:    0,
+		Colouring: Amplitude,
+		Palette:   palettes.NewPalette("test", []color.NRGBA{blue, blue, blue, blue, blue, red, red, red, red, red, red}),
+		AntiAlias: kernels.None,
+	}
+
+	samples := make([]float32, 160)
+	for i := range samples {
+		if i < 80 {
+			samples[i] = []float32{0.1, -0.1}[i%2]
+		} else {
+			samples[i] = []float32{0.9, -0.9}[i%2]
+		}
+	}
+
+	img, err := renderer.Render(samples, 16, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	if colour := img.NRGBAAt(4, 50); colour != blue {
+		t.Errorf("incorrect colour for quiet bar - expected:%v, got:%v", blue, colour)
+	}
+
+	if colour := img.NRGBAAt(12, 50); colour != red {
+		t.Errorf("incorrect colour for loud bar - expected:%v, got:%v", red, colour)
+	}
+}
+
+func TestRenderWithMinHeight(t *testing.T) {
+	renderer := Columns{
+		BarWidth:  8,
+		BarGap:    0,
+		MinHeight: 10,
+		Palette:   palettes.NewPalette("test", []color.NRGBA{blue, red}),
+		AntiAlias: kernels.None,
+	}
+
+	img, err := renderer.Render(make([]float32, 160), 16, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	for y := 0; y < 101; y++ {
+		expected := y >= 45 && y < 55
+		if opaque := img.NRGBAAt(4, y).A > 0; opaque != expected {
+			t.Errorf("incorrect minimum height bar at row %v - expected:%v, got:%v", y, expected, opaque)
+		}
+	}
+}
+
+func TestRenderWithRoundedCaps(t *testing.T) {
+	renderer := Columns{
+		BarWidth:  16,
+		BarGap:    0,
+		Radius:    8,
+		Colouring: Amplitude,
+		Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+	}
+
+	samples := make([]float32, 160)
+	for i := range samples {
+		samples[i] = []float32{0.5, -0.5}[i%2]
+	}
+
+	img, err := renderer.Render(samples, 32, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	top := -1
+	for y := 0; y < 101 && top < 0; y++ {
+		if img.NRGBAAt(9, y).A > 0 {
+			top = y
+		}
+	}
+
+	if top < 0 {
+		t.Fatalf("missing bar")
+	}
+
+	if colour := img.NRGBAAt(2, top); colour.A != 0 {
+		t.Errorf("incorrect colour for rounded corner - expected:transparent, got:%v", colour)
+	}
+
+	if colour := img.NRGBAAt(9, top+4); colour != red {
+		t.Errorf("incorrect colour for bar - expected:%v, got:%v", red, colour)
+	}
+}
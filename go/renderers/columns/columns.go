@@ -1,28 +1,77 @@
 package columns
 
 import (
+	"fmt"
 	"image"
+	"image/color"
+	"math"
+	"strings"
 
 	"golang.org/x/image/draw"
 
 	"github.com/transcriptaze/wav2png/go/kernels"
 	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
 )
 
+// Columns renders a waveform as a series of vertical bars. By default each bar is coloured by the
+// sample density (as for the Lines renderer), but may instead be coloured by the peak amplitude
+// of the bar (quiet bars are coloured from the start of the palette and loud bars from the end of
+// the palette). Radius rounds the ends of each bar and MinHeight sets the minimum height of a bar
+// (e.g. for silence).
+type Columns struct {
+	BarWidth   uint
+	BarGap     uint
+	Radius     uint
+	MinHeight  uint
+	Colouring  Colouring
+	Palette    palettes.Palette
+	AntiAlias  kernels.Kernel
+	Layout     renderers.Layout
+	Gradient   *renderers.Gradient
+	Horizontal *renderers.Horizontal
+}
+
+type Colouring int
+
 const (
-	RANGE_MIN int32 = -32768
-	RANGE_MAX int32 = +32767
-	RANGE     int32 = RANGE_MAX - RANGE_MIN + 1
+	Density Colouring = iota
+	Amplitude
 )
 
-type Columns struct {
-	BarWidth  uint
-	BarGap    uint
-	Palette   palettes.Palette
-	AntiAlias kernels.Kernel
+func ParseColouring(s string) (Colouring, error) {
+	switch strings.ToLower(s) {
+	case "density", "":
+		return Density, nil
+	case "amplitude":
+		return Amplitude, nil
+	}
+
+	return Density, fmt.Errorf("invalid bar colouring (%v)", s)
+}
+
+func (c Colouring) String() string {
+	return [...]string{"density", "amplitude"}[c]
 }
 
 func (c Columns) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	if h := c.Horizontal; h != nil {
+		unplayed := c
+		unplayed.Horizontal = nil
+
+		played := unplayed
+		if h.Played != nil {
+			played.Palette = *h.Played
+			played.Gradient = nil
+		}
+
+		return h.Render(unplayed, played, samples, width, height, padding, vscale)
+	}
+
+	if c.Gradient != nil {
+		c.Palette = renderers.OPAQUE
+	}
+
 	w := width
 	h := height
 	if padding > 0 {
@@ -51,6 +100,9 @@ func (c Columns) render(samples []float32, width, height int, vscale float64) *i
 	bar := image.NewNRGBA(image.Rect(0, 0, 1, int(height)))
 	waveform := image.NewNRGBA(image.Rect(0, 0, int(width), int(height)))
 	colours := c.Palette.Realize()
+	if len(colours) == 0 {
+		return waveform
+	}
 
 	scaler := draw.CatmullRom
 	column := image.Rect(1, 0, int(c.BarWidth), height)
@@ -65,72 +117,143 @@ func (c Columns) render(samples []float32, width, height int, vscale float64) *i
 			end = len(samples)
 		}
 
-		sum := make([]int, height)
-		u := scale(0, -int(height))
-
-		for _, sample := range samples[start:end] {
-			v := int16(32768 * float64(sample) * vscale)
-			h := scale(v, -int(height))
-			dy := signum(int(h) - int(u))
-			for y := int(u); y != int(h); y += dy {
-				sum[y]++
-			}
-		}
+		sum := renderers.Traverse(samples[start:end], height, vscale, c.Layout)
 
 		N := end - start
 
 		draw.Draw(bar, bar.Bounds(), image.Transparent, image.Pt(0, 0), draw.Src)
 
-		for y := 0; y < height; y++ {
-			if sum[y] > 0 {
-				l := len(colours)
-				i := ceil((l-1)*sum[y], N)
-
-				bar.Set(0, y, colours[i])
+		if c.Colouring == Amplitude {
+			colour := colours[renderers.Index(len(colours), amplitude(samples[start:end], vscale))]
+			for y := 0; y < height; y++ {
+				if sum[y] > 0 {
+					bar.Set(0, y, colour)
+				}
+			}
+		} else {
+			for y := 0; y < height; y++ {
+				if sum[y] > 0 {
+					l := len(colours)
+					i := renderers.Ceil((l-1)*sum[y], N)
+
+					bar.Set(0, y, colours[i])
+				}
 			}
 		}
 
+		if c.MinHeight > 0 {
+			c.minimum(bar, colours)
+		}
+
 		xy := image.Pt(x+1, 0)
 		scaler.Scale(waveform, column.Add(xy), bar, bar.Bounds(), draw.Over, nil)
 
+		if c.Radius > 0 {
+			if top, bottom, ok := extent(bar); ok {
+				r := column.Add(xy).Intersect(waveform.Bounds())
+				r.Min.Y = top
+				r.Max.Y = bottom + 1
+
+				round(waveform, r, int(c.Radius))
+			}
+		}
+
 		x += dx
 		start = end
 	}
 
+	if c.Gradient != nil {
+		c.Gradient.Apply(waveform, c.Layout)
+	}
+
 	return kernels.Antialias(waveform, c.AntiAlias)
 }
 
-func signum(N int) int {
-	if N < 0 {
-		return -1
+// minimum extends a bar to the minimum bar height around the layout baseline, filling the
+// extension with the bar colour at the baseline (or the last palette colour if the bar is empty).
+func (c Columns) minimum(bar *image.NRGBA, colours []color.NRGBA) {
+	height := bar.Bounds().Dy()
+	h := int(c.MinHeight)
+	if h > height {
+		h = height
+	}
+
+	y0 := (height - h) / 2
+	switch c.Layout {
+	case renderers.Top:
+		y0 = height - h
+	case renderers.Bottom:
+		y0 = 0
 	}
 
-	return +1
+	fill := colours[len(colours)-1]
+	for y := y0; y < y0+h; y++ {
+		if colour := bar.NRGBAAt(0, y); colour.A > 0 {
+			fill = colour
+			break
+		}
+	}
+
+	for y := y0; y < y0+h; y++ {
+		if bar.NRGBAAt(0, y).A == 0 {
+			bar.SetNRGBA(0, y, fill)
+		}
+	}
 }
 
-func ceil(p int, q int) int {
-	d := p / q
-	r := p % q
+// extent returns the first and last non-transparent rows of a bar.
+func extent(bar *image.NRGBA) (int, int, bool) {
+	top := -1
+	bottom := -1
 
-	if r > 0 {
-		return d + 1
+	for y := 0; y < bar.Bounds().Dy(); y++ {
+		if bar.NRGBAAt(0, y).A > 0 {
+			if top < 0 {
+				top = y
+			}
+			bottom = y
+		}
 	}
 
-	return d
+	return top, bottom, top >= 0
+}
+
+// round rounds the corners of a bar by fading out the pixels outside the corner arcs, with the
+// radius limited to half the width and height of the bar.
+func round(img *image.NRGBA, bar image.Rectangle, radius int) {
+	r := float64(radius)
+	r = math.Min(r, float64(bar.Dx())/2)
+	r = math.Min(r, float64(bar.Dy())/2)
+
+	x0 := float64(bar.Min.X)
+	x1 := float64(bar.Max.X)
+	y0 := float64(bar.Min.Y)
+	y1 := float64(bar.Max.Y)
+
+	for y := bar.Min.Y; y < bar.Max.Y; y++ {
+		for x := bar.Min.X; x < bar.Max.X; x++ {
+			px := float64(x) + 0.5
+			py := float64(y) + 0.5
+			cx := math.Max(x0+r, math.Min(x1-r, px))
+			cy := math.Max(y0+r, math.Min(y1-r, py))
+
+			if d := math.Hypot(px-cx, py-cy); d > r-0.5 {
+				coverage := math.Max(0, math.Min(1, r+0.5-d))
+				colour := img.NRGBAAt(x, y)
+				colour.A = uint8(math.Round(float64(colour.A) * coverage))
+
+				img.SetNRGBA(x, y, colour)
+			}
+		}
+	}
 }
 
-// vscale maps the 16-bit internal sample value to a pixel range [0..height). i.e. for a
-// height of 256 pixels, vscale maps -32768 to 0 and +32767 to 255. A negative height
-// 'flips' the conversion e.g. for height of -256, -32768 is mapped to 255 and +32767 is
-// mapped to 0.
-func scale(v int16, height int) int16 {
-	h := int32(height)
-	vv := int32(v) - RANGE_MIN
-	vvv := int16(h * vv / RANGE)
-
-	if height < 0 {
-		return vvv - int16(height+1)
+// amplitude returns the peak absolute sample value, clamped to 1.0.
+func amplitude(samples []float32, vscale float64) float64 {
+	peak := 0.0
+	for _, sample := range samples {
+		peak = math.Max(peak, math.Abs(float64(sample)*vscale))
 	}
 
-	return vvv
+	return math.Min(peak, 1.0)
 }
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"fmt"
 	"image"
+	"image/color"
 	"image/png"
 	"os"
 	"reflect"
@@ -42,6 +43,32 @@ func TestRender(t *testing.T) {
 	}
 }
 
+func TestRenderWithEmptyPalette(t *testing.T) {
+	renderer := Columns{
+		BarWidth:  16,
+		BarGap:    1,
+		MinHeight: 4,
+		Palette:   palettes.NewPalette("empty", []color.NRGBA{}),
+		AntiAlias: kernels.None,
+	}
+
+	audio := read()
+	samples := mix(audio, []int{1}...)[0:16000]
+
+	img, err := renderer.Render(samples, 640, 480, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	for y := 0; y < img.Bounds().Dy(); y++ {
+		for x := 0; x < img.Bounds().Dx(); x++ {
+			if c := img.NRGBAAt(x, y); c.A != 0 {
+				t.Fatalf("incorrectly rendered empty palette - expected transparent pixel at (%v,%v), got:%v", x, y, c)
+			}
+		}
+	}
+}
+
 func read() encoding.Audio {
 	r := bytes.NewBuffer(audio)
 	w, _ := wav.Decode(r)
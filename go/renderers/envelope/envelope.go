@@ -0,0 +1,94 @@
+package envelope
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// Envelope renders the exact per-column min/max envelope of a waveform as a filled area. Unlike
+// the lines renderer, the rendered image does not depend on the sample density so a waveform
+// looks the same irrespective of the sample rate or zoom level.
+//
+// Each column is coloured from the palette, indexed by the peak amplitude of the column. If Mirror
+// is set the envelope is drawn symmetrically around the midline using the larger of |min| and
+// |max|.
+type Envelope struct {
+	Palette   palettes.Palette
+	Mirror    bool
+	AntiAlias kernels.Kernel
+}
+
+func (e Envelope) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	w := width
+	h := height
+	if padding > 0 {
+		w = width - 2*padding
+		h = height - 2*padding
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	waveform := e.render(samples, w, h, vscale)
+
+	x0 := padding
+	y0 := padding
+	x1 := x0 + w
+	y1 := y0 + h
+
+	origin := image.Pt(0, 0)
+	rect := image.Rect(x0, y0, x1, y1)
+
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.Draw(img, rect, waveform, origin, draw.Over)
+
+	return img, nil
+}
+
+func (e Envelope) render(samples []float32, width, height int, vscale float64) *image.NRGBA {
+	waveform := image.NewNRGBA(image.Rect(0, 0, width, height))
+	colours := e.Palette.Realize()
+	N := len(samples)
+
+	if len(colours) == 0 || N == 0 {
+		return waveform
+	}
+
+	for x := 0; x < width; x++ {
+		start := x * N / width
+		end := (x + 1) * N / width
+		if end <= start {
+			end = start + 1
+		}
+
+		min := math.MaxFloat64
+		max := -math.MaxFloat64
+
+		for _, sample := range samples[start:end] {
+			v := float64(sample) * vscale
+
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+
+		min = renderers.Clamp(min)
+		max = renderers.Clamp(max)
+		peak := math.Max(math.Abs(min), math.Abs(max))
+
+		if e.Mirror {
+			min = -peak
+			max = peak
+		}
+
+		colour := colours[renderers.Index(len(colours), peak)]
+		for y := renderers.Row(max, height); y <= renderers.Row(min, height); y++ {
+			waveform.Set(x, y, colour)
+		}
+	}
+
+	return kernels.Antialias(waveform, e.AntiAlias)
+}
@@ -0,0 +1,103 @@
+package envelope
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+var red = color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+var transparent = color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x00}
+
+func TestRender(t *testing.T) {
+	renderer := Envelope{
+		Palette:   palettes.NewPalette("envelope", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+	}
+
+	samples := []float32{0.5, -0.1, 0.2, -0.1}
+
+	img, err := renderer.Render(samples, 1, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	tests := []struct {
+		y        int
+		expected color.NRGBA
+	}{
+		{24, transparent},
+		{25, red},
+		{50, red},
+		{55, red},
+		{56, transparent},
+	}
+
+	for _, v := range tests {
+		if colour := img.NRGBAAt(0, v.y); colour != v.expected {
+			t.Errorf("incorrect colour at (%v,%v) - expected:%v, got:%v", 0, v.y, v.expected, colour)
+		}
+	}
+}
+
+func TestRenderMirrored(t *testing.T) {
+	renderer := Envelope{
+		Palette:   palettes.NewPalette("envelope", []color.NRGBA{red}),
+		Mirror:    true,
+		AntiAlias: kernels.None,
+	}
+
+	samples := []float32{0.5, -0.1, 0.2, -0.1}
+
+	img, err := renderer.Render(samples, 1, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	tests := []struct {
+		y        int
+		expected color.NRGBA
+	}{
+		{24, transparent},
+		{25, red},
+		{75, red},
+		{76, transparent},
+	}
+
+	for _, v := range tests {
+		if colour := img.NRGBAAt(0, v.y); colour != v.expected {
+			t.Errorf("incorrect colour at (%v,%v) - expected:%v, got:%v", 0, v.y, v.expected, colour)
+		}
+	}
+}
+
+func TestRenderIsIndependentOfSampleDensity(t *testing.T) {
+	renderer := Envelope{
+		Palette:   palettes.NewPalette("envelope", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+	}
+
+	sparse := []float32{0.5, -0.5, 0.5, -0.5}
+	dense := make([]float32, 1000)
+	for i := range dense {
+		dense[i] = 0.5 - float32(i%2)
+	}
+
+	img1, err := renderer.Render(sparse, 2, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	img2, err := renderer.Render(dense, 2, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	for y := 0; y < 101; y++ {
+		if img1.NRGBAAt(0, y) != img2.NRGBAAt(0, y) {
+			t.Fatalf("rendered envelope depends on sample density at (%v,%v)", 0, y)
+		}
+	}
+}
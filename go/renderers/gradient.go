@@ -0,0 +1,114 @@
+package renderers
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+// Gradient colours a waveform by the distance from the centre line (or baseline), relative to the
+// extent of the waveform in each column. The colour runs from the first colour at the centre line
+// through the second colour at the midpoint to the third colour at the peak. A two colour gradient
+// fades the second colour out to transparent at the peak (as for the WebGPU 'gradient' and
+// 'gradient3' styles).
+type Gradient struct {
+	Colours  []color.NRGBA
+	Midpoint float64
+}
+
+const MIDPOINT = 0.5
+
+// OPAQUE is the palette used to render the waveform coverage for gradient colouring.
+var OPAQUE = palettes.NewPalette("opaque", []color.NRGBA{{R: 0xff, G: 0xff, B: 0xff, A: 0xff}})
+
+func NewGradient(colours []color.NRGBA, midpoint float64) (*Gradient, error) {
+	if len(colours) != 2 && len(colours) != 3 {
+		return nil, fmt.Errorf("invalid gradient - expected 2 or 3 colours (%v)", len(colours))
+	}
+
+	if midpoint < 0 || midpoint > 1 || math.IsNaN(midpoint) {
+		return nil, fmt.Errorf("invalid gradient midpoint (%v)", midpoint)
+	}
+
+	return &Gradient{
+		Colours:  append([]color.NRGBA{}, colours...),
+		Midpoint: midpoint,
+	}, nil
+}
+
+// Colour returns the gradient colour at the fractional distance f from the centre line.
+func (g Gradient) Colour(f float64) color.NRGBA {
+	if len(g.Colours) == 0 {
+		return color.NRGBA{}
+	}
+
+	c1 := g.Colours[0]
+	c2 := c1
+	c3 := c1
+	if len(g.Colours) > 1 {
+		c2 = g.Colours[1]
+		c3 = color.NRGBA{R: c2.R, G: c2.G, B: c2.B, A: 0}
+	}
+
+	if len(g.Colours) > 2 {
+		c3 = g.Colours[2]
+	}
+
+	f = math.Max(0, math.Min(1, f))
+
+	switch {
+	case f <= g.Midpoint && g.Midpoint > 0:
+		return palettes.Lerp(c1, c2, f/g.Midpoint)
+
+	case f <= g.Midpoint:
+		return c2
+
+	case g.Midpoint < 1:
+		return palettes.Lerp(c2, c3, (f-g.Midpoint)/(1-g.Midpoint))
+
+	default:
+		return c3
+	}
+}
+
+// Apply recolours the non-transparent pixels of a waveform image with the gradient, scaling the
+// gradient alpha by the alpha of the pixel (i.e. the pixel coverage). The image should be rendered
+// with an opaque palette. As with the anti-aliasing kernels, pixels are addressed from (0,0).
+func (g Gradient) Apply(img *image.NRGBA, layout Layout) {
+	w := img.Bounds().Dx()
+	h := img.Bounds().Dy()
+
+	baseline := float64(h) / 2
+	switch layout {
+	case Top:
+		baseline = float64(h)
+	case Bottom:
+		baseline = 0
+	}
+
+	for x := 0; x < w; x++ {
+		extent := 0.0
+		for y := 0; y < h; y++ {
+			if img.NRGBAAt(x, y).A > 0 {
+				extent = math.Max(extent, math.Abs(float64(y)+0.5-baseline))
+			}
+		}
+
+		for y := 0; y < h; y++ {
+			if a := img.NRGBAAt(x, y).A; a > 0 {
+				f := 0.0
+				if extent > 0.5 {
+					f = (math.Abs(float64(y)+0.5-baseline) - 0.5) / (extent - 0.5)
+				}
+
+				c := g.Colour(f)
+				c.A = uint8((uint32(c.A)*uint32(a) + 127) / 255)
+
+				img.SetNRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package renderers
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestGradientColour(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	green := color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+
+	tests := []struct {
+		colours  []color.NRGBA
+		midpoint float64
+		f        float64
+		expected color.NRGBA
+	}{
+		{[]color.NRGBA{red, green, blue}, 0.5, 0.0, red},
+		{[]color.NRGBA{red, green, blue}, 0.5, 0.25, color.NRGBA{R: 0x80, G: 0x80, B: 0x00, A: 0xff}},
+		{[]color.NRGBA{red, green, blue}, 0.5, 0.5, green},
+		{[]color.NRGBA{red, green, blue}, 0.5, 1.0, blue},
+		{[]color.NRGBA{red, green, blue}, 0.25, 0.625, color.NRGBA{R: 0x00, G: 0x80, B: 0x80, A: 0xff}},
+		{[]color.NRGBA{red, green}, 0.5, 0.5, green},
+		{[]color.NRGBA{red, green}, 0.5, 0.75, color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0x80}},
+		{[]color.NRGBA{red, green}, 0.5, 1.0, color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0x00}},
+	}
+
+	for _, test := range tests {
+		gradient, err := NewGradient(test.colours, test.midpoint)
+		if err != nil {
+			t.Fatalf("error creating gradient (%v)", err)
+		}
+
+		if c := gradient.Colour(test.f); c != test.expected {
+			t.Errorf("incorrect gradient colour at %v - expected:%v, got:%v", test.f, test.expected, c)
+		}
+	}
+}
+
+func TestGradientApply(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gradient := Gradient{Colours: []color.NRGBA{red, blue, blue}, Midpoint: 1.0}
+
+	tests := []struct {
+		layout   Layout
+		rows     []int
+		expected map[int]color.NRGBA
+	}{
+		{Bipolar, []int{2, 3, 4, 5}, map[int]color.NRGBA{2: blue, 3: red, 4: red, 5: blue}},
+		{Top, []int{5, 6, 7}, map[int]color.NRGBA{5: blue, 6: {R: 0x80, G: 0x00, B: 0x80, A: 0xff}, 7: red}},
+		{Bottom, []int{0, 1, 2}, map[int]color.NRGBA{0: red, 1: {R: 0x80, G: 0x00, B: 0x80, A: 0xff}, 2: blue}},
+	}
+
+	for _, test := range tests {
+		img := image.NewNRGBA(image.Rect(0, 0, 1, 8))
+		for _, y := range test.rows {
+			img.SetNRGBA(0, y, white)
+		}
+
+		gradient.Apply(img, test.layout)
+
+		for y := 0; y < 8; y++ {
+			expected := test.expected[y]
+			if c := img.NRGBAAt(0, y); c != expected {
+				t.Errorf("incorrect %v gradient pixel at %v - expected:%v, got:%v", test.layout, y, expected, c)
+			}
+		}
+	}
+}
+
+func TestInvalidGradient(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+
+	if _, err := NewGradient([]color.NRGBA{red}, 0.5); err == nil {
+		t.Errorf("expected error for single colour gradient")
+	}
+
+	if _, err := NewGradient([]color.NRGBA{red, red}, 1.5); err == nil {
+		t.Errorf("expected error for invalid midpoint")
+	}
+}
@@ -0,0 +1,99 @@
+package renderers
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+// Horizontal colours a waveform along the horizontal axis:
+//   - the part of the waveform to the left of the playhead (a fraction of the waveform width) is
+//     coloured with the Played palette (e.g. for progress visuals in wav2mp4)
+//   - the waveform colours are tinted with a left to right gradient through the Colours, retaining
+//     the alpha of the waveform
+//
+// Either (or both) may be specified. If both are specified the gradient tints only the unplayed part
+// of the waveform, so that the played part retains the colours of the Played palette.
+type Horizontal struct {
+	Played   *palettes.Palette
+	Playhead float64
+	Colours  []color.NRGBA
+}
+
+func NewHorizontal(played *palettes.Palette, playhead float64, colours []color.NRGBA) (*Horizontal, error) {
+	if playhead < 0 || playhead > 1 || math.IsNaN(playhead) {
+		return nil, fmt.Errorf("invalid playhead (%v)", playhead)
+	}
+
+	if len(colours) == 1 {
+		return nil, fmt.Errorf("invalid horizontal gradient - expected at least 2 colours (%v)", len(colours))
+	}
+
+	return &Horizontal{
+		Played:   played,
+		Playhead: playhead,
+		Colours:  append([]color.NRGBA{}, colours...),
+	}, nil
+}
+
+// WithPlayhead returns a copy of the horizontal colouring with the playhead at the (clamped) position.
+func (h Horizontal) WithPlayhead(playhead float64) *Horizontal {
+	h.Playhead = math.Max(0, math.Min(1, playhead))
+
+	return &h
+}
+
+// Render renders the waveform with the 'unplayed' renderer, replacing the part of the waveform to
+// the left of the playhead with the waveform rendered by the 'played' renderer (if not nil) and
+// then applying the horizontal gradient (if any).
+func (h Horizontal) Render(unplayed, played Renderer, samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	img, err := unplayed.Render(samples, width, height, padding, vscale)
+	if err != nil {
+		return nil, err
+	}
+
+	x0 := max(0, padding)
+	w := width - 2*x0
+	unplayedx := 0
+
+	if played != nil && h.Played != nil {
+		if p, err := played.Render(samples, width, height, padding, vscale); err != nil {
+			return nil, err
+		} else {
+			x := x0 + int(math.Round(h.Playhead*float64(w-1)))
+			r := image.Rect(0, 0, x, height)
+
+			draw.Draw(img, r, p, r.Min, draw.Src)
+			unplayedx = x
+		}
+	}
+
+	if len(h.Colours) > 1 {
+		for x := unplayedx; x < width; x++ {
+			t := 0.0
+			if w > 1 {
+				t = math.Max(0, math.Min(1, float64(x-x0)/float64(w-1)))
+			}
+
+			tint := palettes.Interpolate(h.Colours, t)
+
+			for y := 0; y < height; y++ {
+				if c := img.NRGBAAt(x, y); c.A > 0 {
+					img.SetNRGBA(x, y, color.NRGBA{
+						R: tint.R,
+						G: tint.G,
+						B: tint.B,
+						A: uint8((uint32(c.A)*uint32(tint.A) + 127) / 255),
+					})
+				}
+			}
+		}
+	}
+
+	return img, nil
+}
@@ -0,0 +1,116 @@
+package renderers
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+type solid color.NRGBA
+
+func (s solid) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.NRGBA(s)), image.Pt(0, 0), draw.Src)
+
+	return img, nil
+}
+
+func TestHorizontalPlayhead(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+	played := palettes.NewPalette("played", []color.NRGBA{red})
+
+	horizontal, err := NewHorizontal(&played, 0.5, nil)
+	if err != nil {
+		t.Fatalf("error creating horizontal colouring (%v)", err)
+	}
+
+	img, err := horizontal.Render(solid(blue), solid(red), nil, 12, 4, 1, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering waveform (%v)", err)
+	}
+
+	// ... waveform width is 10 so playhead is at pixel 1 + round(0.5*9)
+	for x := 0; x < 12; x++ {
+		expected := blue
+		if x < 6 {
+			expected = red
+		}
+
+		if c := img.NRGBAAt(x, 2); c != expected {
+			t.Errorf("incorrect colour at %v - expected:%v, got:%v", x, expected, c)
+		}
+	}
+
+	// ... playhead moves with WithPlayhead
+	img, _ = horizontal.WithPlayhead(0.0).Render(solid(blue), solid(red), nil, 12, 4, 1, 1.0)
+	if c := img.NRGBAAt(1, 2); c != blue {
+		t.Errorf("incorrect colour at playhead 0 - expected:%v, got:%v", blue, c)
+	}
+}
+
+func TestHorizontalGradient(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x80}
+
+	horizontal, err := NewHorizontal(nil, 0, []color.NRGBA{red, blue})
+	if err != nil {
+		t.Fatalf("error creating horizontal colouring (%v)", err)
+	}
+
+	img, err := horizontal.Render(solid(white), nil, nil, 5, 2, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering waveform (%v)", err)
+	}
+
+	expected := []color.NRGBA{
+		{R: 0xff, G: 0x00, B: 0x00, A: 0x80},
+		{R: 0xbf, G: 0x00, B: 0x40, A: 0x80},
+		{R: 0x80, G: 0x00, B: 0x80, A: 0x80},
+		{R: 0x40, G: 0x00, B: 0xbf, A: 0x80},
+		{R: 0x00, G: 0x00, B: 0xff, A: 0x80},
+	}
+
+	for x, e := range expected {
+		if c := img.NRGBAAt(x, 1); c != e {
+			t.Errorf("incorrect colour at %v - expected:%v, got:%v", x, e, c)
+		}
+	}
+}
+
+func TestHorizontalPlayedWithGradient(t *testing.T) {
+	red := color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+	blue := color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+	green := color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	white := color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	played := palettes.NewPalette("played", []color.NRGBA{green})
+
+	horizontal, err := NewHorizontal(&played, 0.5, []color.NRGBA{red, blue})
+	if err != nil {
+		t.Fatalf("error creating horizontal colouring (%v)", err)
+	}
+
+	img, err := horizontal.Render(solid(white), solid(green), nil, 5, 2, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering waveform (%v)", err)
+	}
+
+	// ... played part retains the played colour, unplayed part is tinted with the gradient
+	expected := []color.NRGBA{
+		green,
+		green,
+		{R: 0x80, G: 0x00, B: 0x80, A: 0xff},
+		{R: 0x40, G: 0x00, B: 0xbf, A: 0xff},
+		{R: 0x00, G: 0x00, B: 0xff, A: 0xff},
+	}
+
+	for x, e := range expected {
+		if c := img.NRGBAAt(x, 1); c != e {
+			t.Errorf("incorrect colour at %v - expected:%v, got:%v", x, e, c)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package renderers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	RANGE_MIN int32 = -32768
+	RANGE_MAX int32 = +32767
+	RANGE     int32 = RANGE_MAX - RANGE_MIN + 1
+)
+
+// Layout determines how sample values are mapped to the vertical axis of a waveform:
+//   - Bipolar maps -1..+1 across the full height around a centre line
+//   - Mirrored maps the absolute amplitude symmetrically above and below the centre line
+//   - Top maps the absolute amplitude upwards from a baseline at the bottom of the image
+//   - Bottom maps the absolute amplitude downwards from a baseline at the top of the image
+type Layout int
+
+const (
+	Bipolar Layout = iota
+	Mirrored
+	Top
+	Bottom
+)
+
+func ParseLayout(s string) (Layout, error) {
+	switch strings.ToLower(s) {
+	case "bipolar", "":
+		return Bipolar, nil
+	case "mirrored":
+		return Mirrored, nil
+	case "top":
+		return Top, nil
+	case "bottom":
+		return Bottom, nil
+	}
+
+	return Bipolar, fmt.Errorf("invalid layout (%v)", s)
+}
+
+func (l Layout) String() string {
+	return [...]string{"bipolar", "mirrored", "top", "bottom"}[l]
+}
+
+// Traverse counts the number of sample-to-sample traversals through each pixel in a column of the
+// given height for the layout.
+func Traverse(samples []float32, height int, vscale float64, layout Layout) []int {
+	sum := make([]int, height)
+
+	switch layout {
+	case Mirrored:
+		u := scale(0, -height)
+		for _, sample := range samples {
+			v := int16(math.Min(32768*level(sample, vscale, layout), float64(RANGE_MAX)))
+			h := scale(v, -height)
+			dy := Signum(int(h) - int(u))
+			for y := int(u); y != int(h); y += dy {
+				sum[y]++
+			}
+		}
+
+		for y := 0; y <= int(u); y++ {
+			sum[height-1-y] = sum[y]
+		}
+
+	case Top, Bottom:
+		H := -height
+		if layout == Bottom {
+			H = height
+		}
+
+		u := scale(int16(RANGE_MIN), H)
+		for _, sample := range samples {
+			v := int16(math.Min(65536*level(sample, vscale, layout)+float64(RANGE_MIN), float64(RANGE_MAX)))
+			h := scale(v, H)
+			dy := Signum(int(h) - int(u))
+			for y := int(u); y != int(h); y += dy {
+				sum[y]++
+			}
+		}
+
+	default:
+		u := scale(0, -height)
+		for _, sample := range samples {
+			v := int16(math.Min(32768*level(sample, vscale, layout), float64(RANGE_MAX)))
+			h := scale(v, -height)
+			dy := Signum(int(h) - int(u))
+			for y := int(u); y != int(h); y += dy {
+				sum[y]++
+			}
+		}
+	}
+
+	return sum
+}
+
+// Positions maps a sample to the (fractional) vertical pixel position of the sample in an image of
+// the given height for the layout, i.e. a single position for the bipolar, top and bottom layouts
+// and the pair of positions above and below the centre line for the mirrored layout.
+func Positions(sample float32, height int, vscale float64, layout Layout) []float64 {
+	H := float64(height - 1)
+	v := level(sample, vscale, layout)
+
+	switch layout {
+	case Mirrored:
+		return []float64{(1 - v) * H / 2, (1 + v) * H / 2}
+
+	case Top:
+		return []float64{(1 - v) * H}
+
+	case Bottom:
+		return []float64{v * H}
+
+	default:
+		return []float64{(1 - v) * H / 2}
+	}
+}
+
+// level maps a sample to the signed amplitude (bipolar layout) or absolute amplitude (mirrored, top
+// and bottom layouts), clamped to [-1,+1].
+func level(sample float32, vscale float64, layout Layout) float64 {
+	v := math.Max(-1, math.Min(1, float64(sample)*vscale))
+
+	if layout != Bipolar {
+		return math.Abs(v)
+	}
+
+	return v
+}
+
+// scale maps the 16-bit internal sample value to a pixel range [0..height). A negative height 'flips'
+// the conversion e.g. for height of -256, -32768 is mapped to 255 and +32767 is mapped to 0.
+func scale(v int16, height int) int16 {
+	h := int32(height)
+	vv := int32(v) - RANGE_MIN
+	vvv := int16(h * vv / RANGE)
+
+	if height < 0 {
+		return vvv - int16(height+1)
+	}
+
+	return vvv
+}
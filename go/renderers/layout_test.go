@@ -0,0 +1,51 @@
+package renderers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTraverse(t *testing.T) {
+	tests := []struct {
+		layout Layout
+		from   int
+		to     int
+	}{
+		{Bipolar, 26, 50},
+		{Mirrored, 26, 74},
+		{Top, 51, 100},
+		{Bottom, 0, 49},
+	}
+
+	for _, v := range tests {
+		sum := Traverse([]float32{0.5}, 101, 1.0, v.layout)
+
+		for y := range sum {
+			if expected := y >= v.from && y <= v.to; (sum[y] > 0) != expected {
+				t.Errorf("incorrect traversal for %v layout at row %v - expected:%v, got:%v", v.layout, y, expected, sum[y] > 0)
+			}
+		}
+	}
+}
+
+func TestPositions(t *testing.T) {
+	tests := []struct {
+		layout   Layout
+		sample   float32
+		expected []float64
+	}{
+		{Bipolar, 0.5, []float64{25}},
+		{Bipolar, -0.5, []float64{75}},
+		{Mirrored, -0.5, []float64{25, 75}},
+		{Top, -0.5, []float64{50}},
+		{Top, 1.0, []float64{0}},
+		{Bottom, 0.5, []float64{50}},
+		{Bottom, 2.0, []float64{100}},
+	}
+
+	for _, v := range tests {
+		if positions := Positions(v.sample, 101, 1.0, v.layout); !reflect.DeepEqual(positions, v.expected) {
+			t.Errorf("incorrect %v layout positions for %v - expected:%v, got:%v", v.layout, v.sample, v.expected, positions)
+		}
+	}
+}
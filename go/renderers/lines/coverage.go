@@ -0,0 +1,191 @@
+package lines
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/vector"
+
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// coverage renders the waveform envelope as a polygon through the sub-pixel per-column minimum
+// and maximum of the (linearly interpolated) samples, rasterised with analytic area coverage
+// anti-aliasing. Pixels are coloured by the sample density, as for the Density mode, with the
+// alpha scaled by the pixel coverage. The anti-aliasing kernel is not applied since the edges are
+// already anti-aliased.
+func (r Lines) coverage(samples []float32, width, height int, vscale float64) *image.NRGBA {
+	waveform := image.NewNRGBA(image.Rect(0, 0, width, height))
+	colours := r.Palette.Realize()
+	if len(colours) == 0 || len(samples) == 0 || width < 1 || height < 1 {
+		return waveform
+	}
+
+	// ... envelope
+	lo, hi := envelope(samples, width, vscale)
+	top := make([]float32, width)
+	bottom := make([]float32, width)
+	H := float64(height)
+
+	for x := 0; x < width; x++ {
+		y0, y1 := 0.0, 0.0
+
+		switch r.Layout {
+		case renderers.Mirrored:
+			a := math.Max(math.Abs(lo[x]), math.Abs(hi[x]))
+			y0, y1 = (1-a)*H/2, (1+a)*H/2
+
+		case renderers.Top:
+			a := math.Max(math.Abs(lo[x]), math.Abs(hi[x]))
+			y0, y1 = H-a*H, H
+
+		case renderers.Bottom:
+			a := math.Max(math.Abs(lo[x]), math.Abs(hi[x]))
+			y0, y1 = 0, a*H
+
+		default:
+			y0, y1 = (1-hi[x])*H/2, (1-lo[x])*H/2
+		}
+
+		// ... at least one pixel thick
+		if y1-y0 < 1 {
+			y0 = math.Max(0, math.Min(H-1, (y0+y1)/2-0.5))
+			y1 = y0 + 1
+		}
+
+		top[x] = float32(y0)
+		bottom[x] = float32(y1)
+	}
+
+	z := vector.NewRasterizer(width, height)
+	z.MoveTo(0, top[0])
+	for x := 0; x < width; x++ {
+		z.LineTo(float32(x)+0.5, top[x])
+	}
+
+	z.LineTo(float32(width), top[width-1])
+	z.LineTo(float32(width), bottom[width-1])
+	for x := width - 1; x >= 0; x-- {
+		z.LineTo(float32(x)+0.5, bottom[x])
+	}
+
+	z.LineTo(0, bottom[0])
+	z.ClosePath()
+
+	mask := image.NewAlpha(image.Rect(0, 0, width, height))
+	z.Draw(mask, mask.Bounds(), image.Opaque, image.Point{})
+
+	// ... colour by density
+	density := image.NewNRGBA(image.Rect(0, 0, width, height))
+	bucket(samples, width, height, vscale, r.Layout, func(x, start, end int, sum []int) {
+		N := end - start
+		for y := 0; y < height; y++ {
+			if sum[y] > 0 {
+				l := len(colours)
+				i := renderers.Ceil((l-1)*sum[y], N)
+				density.Set(x-1, y, colours[i])
+			}
+		}
+	})
+
+	for x := 0; x < width; x++ {
+		column := fill(density, x, colours[len(colours)-1])
+
+		for y := 0; y < height; y++ {
+			if a := mask.AlphaAt(x, y).A; a > 0 {
+				c := column[y]
+				c.A = uint8((uint32(c.A)*uint32(a) + 127) / 255)
+
+				waveform.SetNRGBA(x, y, c)
+			}
+		}
+	}
+
+	if r.Gradient != nil {
+		r.Gradient.Apply(waveform, r.Layout)
+	}
+
+	return waveform
+}
+
+// envelope returns the per-column minimum and maximum of the polyline through the samples, with
+// the samples centred in their 'slots' on the horizontal axis.
+func envelope(samples []float32, width int, vscale float64) ([]float64, []float64) {
+	N := len(samples)
+	dx := float64(width) / float64(N)
+	lo := make([]float64, width)
+	hi := make([]float64, width)
+
+	v := func(i int) float64 {
+		return math.Max(-1, math.Min(1, float64(samples[i])*vscale))
+	}
+
+	value := func(p float64) float64 {
+		i := int(math.Floor(p/dx - 0.5))
+		if i < 0 {
+			return v(0)
+		} else if i >= N-1 {
+			return v(N - 1)
+		}
+
+		t := p/dx - 0.5 - float64(i)
+
+		return (1-t)*v(i) + t*v(i+1)
+	}
+
+	for x := 0; x < width; x++ {
+		p := value(float64(x))
+		q := value(float64(x + 1))
+
+		lo[x] = math.Min(p, q)
+		hi[x] = math.Max(p, q)
+
+		start := max(0, int(math.Ceil(float64(x)/dx-0.5)))
+		end := min(N, int(math.Ceil(float64(x+1)/dx-0.5)))
+
+		for i := start; i < end; i++ {
+			lo[x] = math.Min(lo[x], v(i))
+			hi[x] = math.Max(hi[x], v(i))
+		}
+	}
+
+	return lo, hi
+}
+
+// fill returns the colours for a column of the density image, with transparent pixels replaced
+// by the nearest coloured pixel in the column (or the default colour for an empty column).
+func fill(img *image.NRGBA, x int, colour color.NRGBA) []color.NRGBA {
+	height := img.Bounds().Dy()
+	column := make([]color.NRGBA, height)
+	nearest := make([]int, height)
+
+	last := -1
+	for y := 0; y < height; y++ {
+		if img.NRGBAAt(x, y).A > 0 {
+			last = y
+		}
+		nearest[y] = last
+	}
+
+	last = -1
+	for y := height - 1; y >= 0; y-- {
+		if img.NRGBAAt(x, y).A > 0 {
+			last = y
+		}
+
+		if n := nearest[y]; n < 0 || (last >= 0 && last-y < y-n) {
+			nearest[y] = last
+		}
+	}
+
+	for y := range column {
+		if n := nearest[y]; n >= 0 {
+			column[y] = img.NRGBAAt(x, n)
+		} else {
+			column[y] = colour
+		}
+	}
+
+	return column
+}
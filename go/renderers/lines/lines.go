@@ -6,20 +6,37 @@ import (
 
 	"github.com/transcriptaze/wav2png/go/kernels"
 	"github.com/transcriptaze/wav2png/go/palettes"
-)
-
-const (
-	RANGE_MIN int32 = -32768
-	RANGE_MAX int32 = +32767
-	RANGE     int32 = RANGE_MAX - RANGE_MIN + 1
+	"github.com/transcriptaze/wav2png/go/renderers"
 )
 
 type Lines struct {
-	Palette   palettes.Palette
-	AntiAlias kernels.Kernel
+	Palette    palettes.Palette
+	AntiAlias  kernels.Kernel
+	Layout     renderers.Layout
+	Mode       Mode
+	DotSize    float64
+	Gradient   *renderers.Gradient
+	Horizontal *renderers.Horizontal
 }
 
 func (l Lines) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	if h := l.Horizontal; h != nil {
+		unplayed := l
+		unplayed.Horizontal = nil
+
+		played := unplayed
+		if h.Played != nil {
+			played.Palette = *h.Played
+			played.Gradient = nil
+		}
+
+		return h.Render(unplayed, played, samples, width, height, padding, vscale)
+	}
+
+	if l.Gradient != nil {
+		l.Palette = renderers.OPAQUE
+	}
+
 	w := width
 	h := height
 	if padding > 0 {
@@ -35,7 +52,15 @@ func (l Lines) Render(samples []float32, width, height, padding int, vscale floa
 	rect := image.Rect(x0, y0, x1, y1)
 
 	img := image.NewNRGBA(image.Rect(0, 0, width, height))
-	waveform := l.render(img.SubImage(rect).(*image.NRGBA), samples, vscale)
+	var waveform *image.NRGBA
+
+	if l.Mode == Coverage {
+		waveform = l.coverage(samples, w, h, vscale)
+	} else if l.Mode != Density && len(samples) > 0 && len(samples) <= w {
+		waveform = l.plot(samples, w, h, vscale)
+	} else {
+		waveform = l.render(img.SubImage(rect).(*image.NRGBA), samples, vscale)
+	}
 
 	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
 	draw.Draw(img, rect, waveform, image.Pt(0, 0), draw.Over)
@@ -48,71 +73,71 @@ func (r Lines) render(waveform *image.NRGBA, samples []float32, vscale float64)
 	height := waveform.Bounds().Dy()
 	colours := r.Palette.Realize()
 
-	x := 0
-	dx := 1
-	start := 0
-
-	for start < len(samples) {
-		end := (x + dx) * len(samples) / width
-
-		sum := make([]int, height)
-		u := scale(0, -int(height))
-		for _, sample := range samples[start:end] {
-			v := int16(32768 * float64(sample) * vscale)
-			h := scale(v, -int(height))
-			dy := signum(int(h) - int(u))
-			for y := int(u); y != int(h); y += dy {
-				sum[y]++
-			}
-		}
-
+	bucket(samples, width, height, vscale, r.Layout, func(x, start, end int, sum []int) {
 		N := end - start
 		for y := 0; y < height; y++ {
 			if sum[y] > 0 {
 				l := len(colours)
-				i := ceil((l-1)*sum[y], N)
-				waveform.Set(x+dx, y, colours[i])
+				i := renderers.Ceil((l-1)*sum[y], N)
+				waveform.Set(x, y, colours[i])
 			}
 		}
+	})
 
-		x += dx
-		start = end
+	if r.Gradient != nil {
+		r.Gradient.Apply(waveform, r.Layout)
 	}
 
 	return kernels.Antialias(waveform, r.AntiAlias)
 }
 
-func signum(N int) int {
-	if N < 0 {
-		return -1
+// plot renders the samples as dots or a polyline, coloured with the last colour in the palette.
+func (r Lines) plot(samples []float32, width, height int, vscale float64) *image.NRGBA {
+	waveform := image.NewNRGBA(image.Rect(0, 0, width, height))
+	colours := r.Palette.Realize()
+	if len(colours) == 0 {
+		return waveform
 	}
 
-	return +1
-}
+	colour := colours[len(colours)-1]
+	for _, points := range points(samples, width, height, vscale, r.Layout) {
+		switch r.Mode {
+		case Dots:
+			size := r.DotSize
+			if size <= 0 {
+				size = DOT_SIZE
+			}
+
+			dots(waveform, points, size, colour)
 
-func ceil(p int, q int) int {
-	d := p / q
-	r := p % q
+		case Polyline:
+			polyline(waveform, points, colour)
+		}
+	}
 
-	if r > 0 {
-		return d + 1
+	if r.Gradient != nil {
+		r.Gradient.Apply(waveform, r.Layout)
 	}
 
-	return d
+	return kernels.Antialias(waveform, r.AntiAlias)
 }
 
-// vscale maps the 16-bit internal sample value to a pixel range [0..height). i.e. for a
-// height of 256 pixels, vscale maps -32768 to 0 and +32767 to 255. A negative height
-// 'flips' the conversion e.g. for height of -256, -32768 is mapped to 255 and +32767 is
-// mapped to 0.
-func scale(v int16, height int) int16 {
-	h := int32(height)
-	vv := int32(v) - RANGE_MIN
-	vvv := int16(h * vv / RANGE)
-
-	if height < 0 {
-		return vvv - int16(height+1)
-	}
+// bucket splits the samples into per-pixel columns and counts the number of sample-to-sample
+// traversals through each pixel in a column, invoking the callback function with the column,
+// the sample range [start,end) and the per-pixel counts.
+func bucket(samples []float32, width, height int, vscale float64, layout renderers.Layout, f func(x, start, end int, sum []int)) {
+	x := 0
+	dx := 1
+	start := 0
+
+	for start < len(samples) {
+		end := (x + dx) * len(samples) / width
 
-	return vvv
+		sum := renderers.Traverse(samples[start:end], height, vscale, layout)
+
+		f(x+dx, start, end, sum)
+
+		x += dx
+		start = end
+	}
 }
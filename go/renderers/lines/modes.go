@@ -0,0 +1,161 @@
+package lines
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// Mode selects how the Lines renderer draws the waveform:
+//   - Density colours each pixel by the number of sample-to-sample traversals through the pixel
+//   - Dots draws each sample as an anti-aliased dot
+//   - Polyline draws a single anti-aliased line through the samples
+//   - Coverage draws the sub-pixel accurate waveform envelope with area coverage anti-aliasing
+//
+// Dots and Polyline are intended for 'zoomed in' views and automatically switch to Density when
+// there are more samples than pixels.
+type Mode int
+
+const (
+	Density Mode = iota
+	Dots
+	Polyline
+	Coverage
+)
+
+const DOT_SIZE = 3.0
+
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "density", "":
+		return Density, nil
+	case "dots":
+		return Dots, nil
+	case "polyline":
+		return Polyline, nil
+	case "coverage":
+		return Coverage, nil
+	}
+
+	return Density, fmt.Errorf("invalid lines mode (%v)", s)
+}
+
+func (m Mode) String() string {
+	return [...]string{"density", "dots", "polyline", "coverage"}[m]
+}
+
+// points maps the samples to pixel coordinates for the layout, with each sample centred in its
+// 'slot' on the horizontal axis. The mirrored layout maps the samples to two traces, above and
+// below the centre line.
+func points(samples []float32, width, height int, vscale float64, layout renderers.Layout) [][]point {
+	N := len(samples)
+	dx := float64(width) / float64(N)
+	traces := [][]point{}
+
+	for i, sample := range samples {
+		x := (float64(i) + 0.5) * dx
+
+		for j, y := range renderers.Positions(sample, height, vscale, layout) {
+			if j >= len(traces) {
+				traces = append(traces, make([]point, 0, N))
+			}
+
+			traces[j] = append(traces[j], point{x: x, y: y})
+		}
+	}
+
+	return traces
+}
+
+type point struct {
+	x float64
+	y float64
+}
+
+// dots draws each sample as a filled disc of diameter d, with the edge pixels anti-aliased by
+// the coverage of the pixel.
+func dots(img *image.NRGBA, points []point, d float64, colour color.NRGBA) {
+	r := d / 2
+
+	for _, p := range points {
+		x0 := int(math.Floor(p.x - r - 1))
+		x1 := int(math.Ceil(p.x + r + 1))
+		y0 := int(math.Floor(p.y - r - 1))
+		y1 := int(math.Ceil(p.y + r + 1))
+
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				distance := math.Hypot(float64(x)+0.5-p.x, float64(y)+0.5-p.y)
+				plot(img, x, y, r+0.5-distance, colour)
+			}
+		}
+	}
+}
+
+// polyline draws an anti-aliased line through the points using Xiaolin Wu's line algorithm.
+func polyline(img *image.NRGBA, points []point, colour color.NRGBA) {
+	for i := 1; i < len(points); i++ {
+		wu(img, points[i-1], points[i], colour)
+	}
+
+	if len(points) == 1 {
+		wu(img, points[0], points[0], colour)
+	}
+}
+
+func wu(img *image.NRGBA, p, q point, colour color.NRGBA) {
+	x0, y0 := p.x-0.5, p.y
+	x1, y1 := q.x-0.5, q.y
+
+	steep := math.Abs(y1-y0) > math.Abs(x1-x0)
+	if steep {
+		x0, y0 = y0, x0
+		x1, y1 = y1, x1
+	}
+
+	if x0 > x1 {
+		x0, x1 = x1, x0
+		y0, y1 = y1, y0
+	}
+
+	gradient := 1.0
+	if dx := x1 - x0; dx != 0 {
+		gradient = (y1 - y0) / dx
+	}
+
+	set := func(x, y int, c float64) {
+		if steep {
+			plot(img, y, x, c, colour)
+		} else {
+			plot(img, x, y, c, colour)
+		}
+	}
+
+	for x := int(math.Round(x0)); x <= int(math.Round(x1)); x++ {
+		y := y0 + gradient*(float64(x)-x0)
+		iy := math.Floor(y)
+		f := y - iy
+
+		set(x, int(iy), 1-f)
+		set(x, int(iy)+1, f)
+	}
+}
+
+// plot sets the pixel to the colour with the alpha scaled by the pixel coverage, retaining the
+// existing pixel if it is more opaque.
+func plot(img *image.NRGBA, x, y int, coverage float64, colour color.NRGBA) {
+	if !(image.Point{x, y}.In(img.Bounds())) || coverage <= 0 {
+		return
+	}
+
+	c := colour
+	c.A = uint8(math.Round(float64(colour.A) * math.Min(1, coverage)))
+
+	if img.NRGBAAt(x, y).A < c.A {
+		img.SetNRGBA(x, y, c)
+	}
+}
@@ -0,0 +1,197 @@
+package lines
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+var red = color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+
+func TestRenderDots(t *testing.T) {
+	renderer := Lines{
+		Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+		Mode:      Dots,
+	}
+
+	samples := []float32{0.0, 0.5, 0.0, -0.5}
+
+	img, err := renderer.Render(samples, 40, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	tests := []struct {
+		x, y     int
+		expected bool
+	}{
+		{5, 50, true},
+		{15, 25, true},
+		{25, 50, true},
+		{35, 75, true},
+		{10, 50, false},
+		{15, 50, false},
+		{35, 25, false},
+	}
+
+	for _, v := range tests {
+		if opaque := img.NRGBAAt(v.x, v.y).A > 0; opaque != v.expected {
+			t.Errorf("incorrect pixel at (%v,%v) - expected:%v, got:%v", v.x, v.y, v.expected, opaque)
+		}
+	}
+}
+
+func TestRenderPolyline(t *testing.T) {
+	renderer := Lines{
+		Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+		Mode:      Polyline,
+	}
+
+	samples := []float32{0.0, 0.0}
+
+	img, err := renderer.Render(samples, 40, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	for x := 10; x < 30; x++ {
+		if colour := img.NRGBAAt(x, 50); colour != red {
+			t.Errorf("incorrect pixel at (%v,%v) - expected:%v, got:%v", x, 50, red, colour)
+		}
+
+		if colour := img.NRGBAAt(x, 40); colour.A != 0 {
+			t.Errorf("incorrect pixel at (%v,%v) - expected:transparent, got:%v", x, 40, colour)
+		}
+	}
+}
+
+func TestRenderPolylineWithDenseSamples(t *testing.T) {
+	renderer := Lines{
+		Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias: kernels.None,
+		Mode:      Polyline,
+	}
+
+	samples := make([]float32, 400)
+	for i := range samples {
+		samples[i] = []float32{0.5, -0.5}[i%2]
+	}
+
+	img, err := renderer.Render(samples, 40, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	// ... density rendering fills the column between the sample extremes
+	for y := 30; y < 70; y++ {
+		if colour := img.NRGBAAt(20, y); colour.A == 0 {
+			t.Errorf("incorrect pixel at (%v,%v) - expected:%v, got:%v", 20, y, red, colour)
+		}
+	}
+}
+
+func TestRenderCoverage(t *testing.T) {
+	renderer := Lines{
+		Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias: kernels.Vertical,
+		Mode:      Coverage,
+	}
+
+	samples := make([]float32, 100)
+	for i := range samples {
+		samples[i] = 0.25
+	}
+
+	img, err := renderer.Render(samples, 10, 9, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	// ... envelope is one pixel thick, from y=2.875 to y=3.875
+	expected := []int{0, 0, 32, 223, 0, 0, 0, 0, 0}
+
+	for y, alpha := range expected {
+		if colour := img.NRGBAAt(5, y); int(colour.A) < alpha-1 || int(colour.A) > alpha+1 {
+			t.Errorf("incorrect coverage at (%v,%v) - expected:%v, got:%v", 5, y, alpha, colour.A)
+		}
+	}
+}
+
+func TestRenderDotsWithLayout(t *testing.T) {
+	samples := []float32{0.5, -0.5}
+
+	tests := []struct {
+		layout renderers.Layout
+		opaque []int
+		clear  []int
+	}{
+		{renderers.Bipolar, []int{25}, []int{50, 75}},
+		{renderers.Mirrored, []int{25, 75}, []int{50}},
+		{renderers.Top, []int{50}, []int{25, 75}},
+		{renderers.Bottom, []int{50}, []int{25, 75}},
+	}
+
+	for _, test := range tests {
+		renderer := Lines{
+			Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+			AntiAlias: kernels.None,
+			Layout:    test.layout,
+			Mode:      Dots,
+		}
+
+		img, err := renderer.Render(samples, 20, 101, 0, 1.0)
+		if err != nil {
+			t.Fatalf("error rendering test image (%v)", err)
+		}
+
+		for _, y := range test.opaque {
+			if img.NRGBAAt(5, y).A == 0 {
+				t.Errorf("incorrect %v layout pixel at (%v,%v) - expected:opaque, got:transparent", test.layout, 5, y)
+			}
+		}
+
+		for _, y := range test.clear {
+			if img.NRGBAAt(5, y).A != 0 {
+				t.Errorf("incorrect %v layout pixel at (%v,%v) - expected:transparent, got:opaque", test.layout, 5, y)
+			}
+		}
+	}
+}
+
+func TestRenderPlayedWithVerticalGradient(t *testing.T) {
+	green := color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff}
+	played := palettes.NewPalette("played", []color.NRGBA{green})
+
+	gradient, _ := renderers.NewGradient([]color.NRGBA{red, red}, renderers.MIDPOINT)
+	horizontal, _ := renderers.NewHorizontal(&played, 0.5, nil)
+
+	renderer := Lines{
+		Palette:    palettes.NewPalette("test", []color.NRGBA{red}),
+		AntiAlias:  kernels.None,
+		Gradient:   gradient,
+		Horizontal: horizontal,
+	}
+
+	samples := make([]float32, 400)
+	for i := range samples {
+		samples[i] = 0.5 * float32(1-2*(i%2))
+	}
+
+	img, err := renderer.Render(samples, 40, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	if c := img.NRGBAAt(5, 50); c != green {
+		t.Errorf("incorrect played colour - expected:%v, got:%v", green, c)
+	}
+
+	if c := img.NRGBAAt(35, 50); c != red {
+		t.Errorf("incorrect unplayed colour - expected:%v, got:%v", red, c)
+	}
+}
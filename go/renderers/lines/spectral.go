@@ -0,0 +1,192 @@
+package lines
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// Spectral renders a waveform coloured by its spectral content, in the style of DJ software. The
+// signal is split into low, mid and high frequency bands by a three-band crossover filter and each
+// column is coloured by the mix of the band energies in that column, with low frequencies mapped
+// to red, mid frequencies to green and high frequencies to blue. The opacity of each pixel is
+// derived from the number of sample traversals through the pixel, as for the Lines renderer.
+//
+// Zero values for SampleRate and the crossover frequencies default to 44100Hz, 200Hz and 2000Hz
+// respectively.
+type Spectral struct {
+	SampleRate float64
+	Low        float64
+	High       float64
+	AntiAlias  kernels.Kernel
+	Layout     renderers.Layout
+}
+
+const (
+	SAMPLE_RATE    = 44100.0
+	CROSSOVER_LOW  = 200.0
+	CROSSOVER_HIGH = 2000.0
+	Q              = 1.0 / math.Sqrt2
+)
+
+func (s Spectral) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	w := width
+	h := height
+	if padding > 0 {
+		w = width - 2*padding
+		h = height - 2*padding
+	}
+
+	x0 := padding
+	y0 := padding
+	x1 := x0 + w
+	y1 := y0 + h
+
+	rect := image.Rect(x0, y0, x1, y1)
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	waveform := s.render(img.SubImage(rect).(*image.NRGBA), samples, vscale)
+
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.Draw(img, rect, waveform, image.Pt(0, 0), draw.Over)
+
+	return img, nil
+}
+
+func (s Spectral) render(waveform *image.NRGBA, samples []float32, vscale float64) *image.NRGBA {
+	width := waveform.Bounds().Dx()
+	height := waveform.Bounds().Dy()
+	low, mid, high := s.crossover(samples)
+
+	bucket(samples, width, height, vscale, s.Layout, func(x, start, end int, sum []int) {
+		N := end - start
+		if N == 0 {
+			return
+		}
+
+		r := rms(low[start:end])
+		g := rms(mid[start:end])
+		b := rms(high[start:end])
+		max := math.Max(r, math.Max(g, b))
+		if max == 0 {
+			r, g, b, max = 1, 1, 1, 1
+		}
+
+		for y := 0; y < height; y++ {
+			if sum[y] > 0 {
+				waveform.Set(x, y, color.NRGBA{
+					R: uint8(math.Round(255 * r / max)),
+					G: uint8(math.Round(255 * g / max)),
+					B: uint8(math.Round(255 * b / max)),
+					A: uint8(0x40 + renderers.Ceil(0xbf*sum[y], N)),
+				})
+			}
+		}
+	})
+
+	return kernels.Antialias(waveform, s.AntiAlias)
+}
+
+// crossover splits the samples into low, mid and high frequency bands using second order
+// Butterworth low pass and high pass filters at the crossover frequencies.
+func (s Spectral) crossover(samples []float32) ([]float64, []float64, []float64) {
+	fs := s.SampleRate
+	fL := s.Low
+	fH := s.High
+
+	if fs <= 0 {
+		fs = SAMPLE_RATE
+	}
+
+	if fL <= 0 {
+		fL = CROSSOVER_LOW
+	}
+
+	if fH <= 0 {
+		fH = CROSSOVER_HIGH
+	}
+
+	fL = math.Min(fL, 0.45*fs)
+	fH = math.Max(fL, math.Min(fH, 0.45*fs))
+
+	lpL := lowpass(fL, fs)
+	hpL := highpass(fL, fs)
+	lpH := lowpass(fH, fs)
+	hpH := highpass(fH, fs)
+
+	low := make([]float64, len(samples))
+	mid := make([]float64, len(samples))
+	high := make([]float64, len(samples))
+
+	for i, sample := range samples {
+		v := float64(sample)
+
+		low[i] = lpL.filter(v)
+		mid[i] = lpH.filter(hpL.filter(v))
+		high[i] = hpH.filter(v)
+	}
+
+	return low, mid, high
+}
+
+func rms(samples []float64) float64 {
+	sum := 0.0
+	for _, v := range samples {
+		sum += v * v
+	}
+
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+// biquad is a second order IIR filter (Direct Form I) with the coefficients from the RBJ
+// 'Audio EQ Cookbook'.
+type biquad struct {
+	b0, b1, b2 float64
+	a1, a2     float64
+	x1, x2     float64
+	y1, y2     float64
+}
+
+func lowpass(f, fs float64) *biquad {
+	w := 2 * math.Pi * f / fs
+	alpha := math.Sin(w) / (2 * Q)
+	cos := math.Cos(w)
+	a0 := 1 + alpha
+
+	return &biquad{
+		b0: (1 - cos) / 2 / a0,
+		b1: (1 - cos) / a0,
+		b2: (1 - cos) / 2 / a0,
+		a1: -2 * cos / a0,
+		a2: (1 - alpha) / a0,
+	}
+}
+
+func highpass(f, fs float64) *biquad {
+	w := 2 * math.Pi * f / fs
+	alpha := math.Sin(w) / (2 * Q)
+	cos := math.Cos(w)
+	a0 := 1 + alpha
+
+	return &biquad{
+		b0: (1 + cos) / 2 / a0,
+		b1: -(1 + cos) / a0,
+		b2: (1 + cos) / 2 / a0,
+		a1: -2 * cos / a0,
+		a2: (1 - alpha) / a0,
+	}
+}
+
+func (q *biquad) filter(x float64) float64 {
+	y := q.b0*x + q.b1*q.x1 + q.b2*q.x2 - q.a1*q.y1 - q.a2*q.y2
+
+	q.x2, q.x1 = q.x1, x
+	q.y2, q.y1 = q.y1, y
+
+	return y
+}
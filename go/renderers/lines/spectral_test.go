@@ -0,0 +1,45 @@
+package lines
+
+import (
+	"math"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+)
+
+func TestSpectral(t *testing.T) {
+	tests := []struct {
+		frequency float64
+		channel   string
+	}{
+		{50, "red"},
+		{800, "green"},
+		{8000, "blue"},
+	}
+
+	renderer := Spectral{
+		SampleRate: 44100,
+		AntiAlias:  kernels.None,
+	}
+
+	for _, v := range tests {
+		samples := make([]float32, 44100)
+		for i := range samples {
+			samples[i] = float32(0.5 * math.Sin(2*math.Pi*v.frequency*float64(i)/44100))
+		}
+
+		img, err := renderer.Render(samples, 8, 64, 0, 1.0)
+		if err != nil {
+			t.Fatalf("error rendering test image (%v)", err)
+		}
+
+		colour := img.NRGBAAt(4, 32)
+		channels := map[string]uint8{"red": colour.R, "green": colour.G, "blue": colour.B}
+
+		if colour.A == 0 {
+			t.Errorf("incorrect colour for %vHz - expected opaque pixel, got:%v", v.frequency, colour)
+		} else if channels[v.channel] != 0xff {
+			t.Errorf("incorrect colour for %vHz - expected predominantly %v, got:%v", v.frequency, v.channel, colour)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package radial
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// Radial renders a waveform wrapped around a circle, starting at 12 o'clock and running clockwise,
+// with the sample amplitude rendered as a radial offset from the base radius. Radius is the base
+// radius as a fraction of the largest circle that fits in the image and defaults to 0.5. If Mirror
+// is set the absolute amplitude is rendered both outwards and inwards from the base radius.
+//
+// As for the Lines renderer, pixels are coloured from the palette by the number of sample
+// traversals through the pixel.
+type Radial struct {
+	Radius    float64
+	Mirror    bool
+	Palette   palettes.Palette
+	AntiAlias kernels.Kernel
+}
+
+const RADIUS = 0.5
+
+func (r Radial) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	w := width
+	h := height
+	if padding > 0 {
+		w = width - 2*padding
+		h = height - 2*padding
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	waveform := r.render(samples, w, h, vscale)
+
+	x0 := padding
+	y0 := padding
+	x1 := x0 + w
+	y1 := y0 + h
+
+	origin := image.Pt(0, 0)
+	rect := image.Rect(x0, y0, x1, y1)
+
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.Draw(img, rect, waveform, origin, draw.Over)
+
+	return img, nil
+}
+
+// Geometry returns the centre and outer radius of the circle for an image with the given width
+// and height, e.g. for positioning a cursor.
+func Geometry(width, height int) (float64, float64, float64) {
+	cx := float64(width) / 2
+	cy := float64(height) / 2
+	R := math.Max(0, math.Min(cx, cy)-1)
+
+	return cx, cy, R
+}
+
+func (r Radial) render(samples []float32, width, height int, vscale float64) *image.NRGBA {
+	waveform := image.NewNRGBA(image.Rect(0, 0, width, height))
+	colours := r.Palette.Realize()
+	cx, cy, R := Geometry(width, height)
+
+	if len(colours) == 0 || len(samples) == 0 || R < 1 {
+		return waveform
+	}
+
+	radius := r.Radius
+	if radius <= 0 || radius > 1 {
+		radius = RADIUS
+	}
+
+	// ... one column per pixel of the outer circumference, each column being a radial histogram
+	//     of sample traversals (one bin per pixel of radius)
+	base := radius * R
+	A := math.Min(base, R-base)
+	if A <= 0 {
+		A = math.Max(base, R-base)
+	}
+
+	columns := int(math.Ceil(2 * math.Pi * R))
+	bins := int(math.Ceil(R)) + 1
+	histogram := make([][]int, columns)
+	N := make([]int, columns)
+
+	u := int(math.Round(base))
+	traverse := func(sum []int, v float64) {
+		h := int(math.Round(base + v*A))
+		h = max(0, min(bins-1, h))
+		dy := renderers.Signum(h - u)
+		for y := u; y != h; y += dy {
+			sum[y]++
+		}
+	}
+
+	for x := 0; x < columns; x++ {
+		start := x * len(samples) / columns
+		end := (x + 1) * len(samples) / columns
+		sum := make([]int, bins)
+
+		for _, sample := range samples[start:end] {
+			v := math.Max(-1, math.Min(1, float64(sample)*vscale))
+
+			if r.Mirror {
+				traverse(sum, math.Abs(v))
+				traverse(sum, -math.Abs(v))
+			} else {
+				traverse(sum, v)
+			}
+		}
+
+		histogram[x] = sum
+		N[x] = end - start
+		if r.Mirror {
+			N[x] *= 2
+		}
+	}
+
+	// ... map pixels to columns
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			dx := float64(x) + 0.5 - cx
+			dy := float64(y) + 0.5 - cy
+			rho := int(math.Round(math.Hypot(dx, dy)))
+			if rho >= bins {
+				continue
+			}
+
+			theta := math.Atan2(dx, -dy)
+			if theta < 0 {
+				theta += 2 * math.Pi
+			}
+
+			column := int(theta/(2*math.Pi)*float64(columns)) % columns
+			if sum := histogram[column][rho]; sum > 0 {
+				l := len(colours)
+				i := renderers.Ceil((l-1)*sum, N[column])
+
+				waveform.Set(x, y, colours[min(i, l-1)])
+			}
+		}
+	}
+
+	return kernels.Antialias(waveform, r.AntiAlias)
+}
@@ -0,0 +1,51 @@
+package radial
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+var red = color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		mirror   bool
+		y        int
+		expected bool
+	}{
+		{false, 10, false},
+		{false, 20, true},
+		{false, 35, false},
+		{true, 10, false},
+		{true, 20, true},
+		{true, 35, true},
+	}
+
+	samples := make([]float32, 1024)
+	for i := range samples {
+		samples[i] = 0.5
+	}
+
+	for _, v := range tests {
+		renderer := Radial{
+			Mirror:    v.mirror,
+			Palette:   palettes.NewPalette("test", []color.NRGBA{red}),
+			AntiAlias: kernels.None,
+		}
+
+		img, err := renderer.Render(samples, 101, 101, 0, 1.0)
+		if err != nil {
+			t.Fatalf("error rendering test image (%v)", err)
+		}
+
+		// ... check 12, 3, 6 and 9 o'clock
+		for _, p := range [][2]int{{50, v.y}, {100 - v.y, 50}, {50, 100 - v.y}, {v.y, 50}} {
+			if opaque := img.NRGBAAt(p[0], p[1]).A > 0; opaque != v.expected {
+				t.Errorf("incorrect pixel at (%v,%v) with mirror:%v - expected:%v, got:%v", p[0], p[1], v.mirror, v.expected, opaque)
+			}
+		}
+	}
+}
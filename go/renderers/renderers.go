@@ -2,8 +2,46 @@ package renderers
 
 import (
 	"image"
+	"math"
 )
 
 type Renderer interface {
 	Render(audio []float32, width, height, padding int, scale float64) (*image.NRGBA, error)
 }
+
+// Row maps an amplitude in the range [-1.0,+1.0] to a pixel row, with +1.0 mapped to the
+// top row and -1.0 mapped to the bottom row.
+func Row(v float64, height int) int {
+	return int(math.Round((1.0 - v) * float64(height-1) / 2.0))
+}
+
+// Index maps an amplitude in the range [0.0,1.0] to an index into a palette of N colours.
+func Index(N int, v float64) int {
+	return int(math.Ceil(float64(N-1) * v))
+}
+
+// Clamp limits an amplitude to the range [-1.0,+1.0].
+func Clamp(v float64) float64 {
+	return math.Max(-1.0, math.Min(1.0, v))
+}
+
+// Signum returns -1 for a negative N and +1 otherwise.
+func Signum(N int) int {
+	if N < 0 {
+		return -1
+	}
+
+	return +1
+}
+
+// Ceil returns p/q rounded up, for non-negative p and positive q.
+func Ceil(p int, q int) int {
+	d := p / q
+	r := p % q
+
+	if r > 0 {
+		return d + 1
+	}
+
+	return d
+}
@@ -0,0 +1,95 @@
+package rms
+
+import (
+	"image"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+// RMS renders a waveform as two layers: the per-column peak (min/max) envelope overlaid with the
+// per-column RMS 'body'. Each layer is coloured from its own palette, indexed by the amplitude of
+// the column i.e. quiet columns take their colour from the start of the palette and loud columns
+// from the end of the palette.
+type RMS struct {
+	Peak      palettes.Palette
+	RMS       palettes.Palette
+	AntiAlias kernels.Kernel
+}
+
+func (r RMS) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	w := width
+	h := height
+	if padding > 0 {
+		w = width - 2*padding
+		h = height - 2*padding
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	waveform := r.render(samples, w, h, vscale)
+
+	x0 := padding
+	y0 := padding
+	x1 := x0 + w
+	y1 := y0 + h
+
+	origin := image.Pt(0, 0)
+	rect := image.Rect(x0, y0, x1, y1)
+
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.Draw(img, rect, waveform, origin, draw.Over)
+
+	return img, nil
+}
+
+func (r RMS) render(samples []float32, width, height int, vscale float64) *image.NRGBA {
+	waveform := image.NewNRGBA(image.Rect(0, 0, width, height))
+	peaks := r.Peak.Realize()
+	rms := r.RMS.Realize()
+
+	for x := 0; x < width; x++ {
+		start := x * len(samples) / width
+		end := (x + 1) * len(samples) / width
+		if end <= start {
+			continue
+		}
+
+		min := math.MaxFloat64
+		max := -math.MaxFloat64
+		sum := 0.0
+
+		for _, sample := range samples[start:end] {
+			v := float64(sample) * vscale
+
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+			sum += v * v
+		}
+
+		N := float64(end - start)
+		peak := renderers.Clamp(math.Max(math.Abs(min), math.Abs(max)))
+		amplitude := renderers.Clamp(math.Sqrt(sum / N))
+
+		// ... peak layer
+		if len(peaks) > 0 {
+			colour := peaks[renderers.Index(len(peaks), peak)]
+			for y := renderers.Row(renderers.Clamp(max), height); y <= renderers.Row(renderers.Clamp(min), height); y++ {
+				waveform.Set(x, y, colour)
+			}
+		}
+
+		// ... RMS layer
+		if len(rms) > 0 {
+			colour := rms[renderers.Index(len(rms), amplitude)]
+			for y := renderers.Row(amplitude, height); y <= renderers.Row(-amplitude, height); y++ {
+				waveform.Set(x, y, colour)
+			}
+		}
+	}
+
+	return kernels.Antialias(waveform, r.AntiAlias)
+}
@@ -0,0 +1,111 @@
+package rms
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+var red = color.NRGBA{R: 0xff, G: 0x00, B: 0x00, A: 0xff}
+var blue = color.NRGBA{R: 0x00, G: 0x00, B: 0xff, A: 0xff}
+var transparent = color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x00}
+
+func TestRender(t *testing.T) {
+	renderer := RMS{
+		Peak:      palettes.NewPalette("peak", []color.NRGBA{red}),
+		RMS:       palettes.NewPalette("rms", []color.NRGBA{blue}),
+		AntiAlias: kernels.None,
+	}
+
+	samples := make([]float32, 64*4)
+	for i := 0; i < 64; i++ {
+		copy(samples[4*i:], []float32{0.8, -0.8, 0.1, -0.1})
+	}
+
+	img, err := renderer.Render(samples, 64, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	tests := []struct {
+		y        int
+		expected color.NRGBA
+	}{
+		{5, transparent},
+		{15, red},
+		{50, blue},
+		{85, red},
+		{95, transparent},
+	}
+
+	for x := 0; x < 64; x++ {
+		for _, v := range tests {
+			if colour := img.NRGBAAt(x, v.y); colour != v.expected {
+				t.Fatalf("incorrect colour at (%v,%v) - expected:%v, got:%v", x, v.y, v.expected, colour)
+			}
+		}
+	}
+}
+
+func TestRenderWithPalette(t *testing.T) {
+	renderer := RMS{
+		Peak:      palettes.NewPalette("peak", []color.NRGBA{transparent, blue, red}),
+		RMS:       palettes.NewPalette("rms", []color.NRGBA{}),
+		AntiAlias: kernels.None,
+	}
+
+	samples := []float32{0.25, -0.25, 0.75, -0.75}
+
+	img, err := renderer.Render(samples, 2, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	if colour := img.NRGBAAt(0, 50); colour != blue {
+		t.Errorf("incorrect colour for quiet column - expected:%v, got:%v", blue, colour)
+	}
+
+	if colour := img.NRGBAAt(1, 50); colour != red {
+		t.Errorf("incorrect colour for loud column - expected:%v, got:%v", red, colour)
+	}
+}
+
+func TestRenderPeakExtent(t *testing.T) {
+	renderer := RMS{
+		Peak:      palettes.NewPalette("peak", []color.NRGBA{red}),
+		RMS:       palettes.NewPalette("rms", []color.NRGBA{}),
+		AntiAlias: kernels.None,
+	}
+
+	tests := []struct {
+		samples []float32
+		vscale  float64
+		top     int
+		bottom  int
+	}{
+		{[]float32{0.5, -0.3}, 1.0, 25, 65},
+		{[]float32{0.3, -0.5}, 1.0, 35, 75},
+		{[]float32{0.8, -0.9}, 2.0, 0, 100},
+	}
+
+	for _, test := range tests {
+		img, err := renderer.Render(test.samples, 1, 101, 0, test.vscale)
+		if err != nil {
+			t.Fatalf("error rendering test image (%v)", err)
+		}
+
+		for y := 0; y < 101; y++ {
+			expected := transparent
+			if y >= test.top && y <= test.bottom {
+				expected = red
+			}
+
+			if colour := img.NRGBAAt(0, y); colour != expected {
+				t.Errorf("incorrect colour at (0,%v) for samples %v - expected:%v, got:%v", y, test.samples, expected, colour)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package spectrogram
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// fft computes the in-place discrete Fourier transform of x using an iterative radix-2
+// Cooley-Tukey FFT. The length of x must be a power of 2.
+func fft(x []complex128) error {
+	N := len(x)
+	if N == 0 || N&(N-1) != 0 {
+		return fmt.Errorf("invalid FFT size (%v)", N)
+	}
+
+	// ... bit reversal permutation
+	shift := 64 - bits.TrailingZeros(uint(N))
+	if N > 1 {
+		for i := 0; i < N; i++ {
+			j := int(bits.Reverse64(uint64(i)) >> shift)
+			if j > i {
+				x[i], x[j] = x[j], x[i]
+			}
+		}
+	}
+
+	// ... butterflies
+	for size := 2; size <= N; size <<= 1 {
+		half := size / 2
+		w := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+
+		for start := 0; start < N; start += size {
+			twiddle := complex(1, 0)
+			for k := 0; k < half; k++ {
+				even := x[start+k]
+				odd := x[start+k+half] * twiddle
+
+				x[start+k] = even + odd
+				x[start+k+half] = even - odd
+				twiddle *= w
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package spectrogram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Scale is the frequency axis scale of a spectrogram.
+type Scale int
+
+const (
+	Linear Scale = iota
+	Log
+	Mel
+)
+
+func ParseScale(s string) (Scale, error) {
+	switch strings.ToLower(s) {
+	case "linear":
+		return Linear, nil
+	case "log", "logarithmic":
+		return Log, nil
+	case "mel":
+		return Mel, nil
+	}
+
+	return Linear, fmt.Errorf("invalid frequency scale (%v)", s)
+}
+
+func (s Scale) String() string {
+	return [...]string{"linear", "log", "mel"}[s]
+}
+
+// frequency maps a position p in the range [0.0,1.0] on the frequency axis to a frequency in the
+// range [fmin,fmax].
+func (s Scale) frequency(p, fmin, fmax float64) float64 {
+	switch s {
+	case Log:
+		return fmin * math.Pow(fmax/fmin, p)
+
+	case Mel:
+		m0 := mel(fmin)
+		m1 := mel(fmax)
+
+		return hz(m0 + p*(m1-m0))
+
+	default:
+		return p * fmax
+	}
+}
+
+func mel(f float64) float64 {
+	return 2595.0 * math.Log10(1.0+f/700.0)
+}
+
+func hz(m float64) float64 {
+	return 700.0 * (math.Pow(10, m/2595.0) - 1.0)
+}
@@ -0,0 +1,226 @@
+package spectrogram
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"golang.org/x/image/draw"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+// Spectrogram renders the short-time Fourier transform of the audio, with time on the horizontal
+// axis and frequency on the vertical axis. The magnitude of each frequency bin is converted to dB
+// and mapped through the palette, with MinDB (and below) mapped to the start of the palette and
+// MaxDB (and above) mapped to the end of the palette.
+//
+// Zero values for FFTSize, Hop, SampleRate and MinDB/MaxDB default to 1024 samples, FFTSize/4,
+// 44100Hz and -90dB..0dB respectively.
+type Spectrogram struct {
+	FFTSize    int
+	Hop        int
+	Window     Window
+	Scale      Scale
+	SampleRate float64
+	MinDB      float64
+	MaxDB      float64
+	Palette    palettes.Palette
+}
+
+const (
+	FFT_SIZE    = 1024
+	SAMPLE_RATE = 44100.0
+	MIN_DB      = -90.0
+	MAX_DB      = 0.0
+)
+
+func (s Spectrogram) Render(samples []float32, width, height, padding int, vscale float64) (*image.NRGBA, error) {
+	w := width
+	h := height
+	if padding > 0 {
+		w = width - 2*padding
+		h = height - 2*padding
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	spectrogram, err := s.render(samples, w, h, vscale)
+	if err != nil {
+		return nil, err
+	}
+
+	x0 := padding
+	y0 := padding
+	x1 := x0 + w
+	y1 := y0 + h
+
+	origin := image.Pt(0, 0)
+	rect := image.Rect(x0, y0, x1, y1)
+
+	draw.Draw(img, img.Bounds(), image.Transparent, image.Point{}, draw.Src)
+	draw.Draw(img, rect, spectrogram, origin, draw.Over)
+
+	return img, nil
+}
+
+func (s Spectrogram) render(samples []float32, width, height int, vscale float64) (*image.NRGBA, error) {
+	N := s.FFTSize
+	hop := s.Hop
+	fs := s.SampleRate
+	minDB := s.MinDB
+	maxDB := s.MaxDB
+
+	if N == 0 {
+		N = FFT_SIZE
+	}
+
+	if hop == 0 {
+		hop = N / 4
+	}
+
+	if fs == 0 {
+		fs = SAMPLE_RATE
+	}
+
+	if minDB == maxDB {
+		minDB = MIN_DB
+		maxDB = MAX_DB
+	}
+
+	if N < 2 || N&(N-1) != 0 {
+		return nil, fmt.Errorf("invalid FFT size (%v)", N)
+	} else if hop < 1 {
+		return nil, fmt.Errorf("invalid FFT hop size (%v)", hop)
+	} else if fs < 0 {
+		return nil, fmt.Errorf("invalid sample rate (%v)", fs)
+	} else if minDB > maxDB {
+		return nil, fmt.Errorf("invalid dB range (%v..%v)", minDB, maxDB)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	colours := s.Palette.Realize()
+	if len(colours) == 0 || len(samples) == 0 || width < 1 || height < 1 {
+		return img, nil
+	}
+
+	// ... precompute frequency axis
+	bins := N / 2
+	fmin := fs / float64(N)
+	fmax := fs / 2
+	rows := make([]float64, height)
+
+	for y := range rows {
+		p := 0.0
+		if height > 1 {
+			p = float64(height-1-y) / float64(height-1)
+		}
+
+		f := s.Scale.frequency(p, fmin, fmax)
+		rows[y] = math.Max(0, math.Min(float64(bins), f*float64(N)/fs))
+	}
+
+	// ... render columns
+	stft := newSTFT(samples, N, hop, s.Window, vscale)
+	frames := stft.frames()
+	spectrum := make([]float64, bins+1)
+
+	for x := 0; x < width; x++ {
+		start := x * frames / width
+		end := (x + 1) * frames / width
+		if end <= start {
+			end = start + 1
+		}
+
+		for i := range spectrum {
+			spectrum[i] = 0
+		}
+
+		for frame := start; frame < end; frame++ {
+			for i, v := range stft.spectrum(frame) {
+				spectrum[i] = math.Max(spectrum[i], v)
+			}
+		}
+
+		for y, bin := range rows {
+			k := int(math.Floor(bin))
+			f := bin - float64(k)
+			magnitude := spectrum[k]
+			if k < bins {
+				magnitude = (1-f)*spectrum[k] + f*spectrum[k+1]
+			}
+
+			dB := 20 * math.Log10(math.Max(magnitude, 1e-12))
+			v := math.Max(0, math.Min(1, (dB-minDB)/(maxDB-minDB)))
+
+			img.Set(x, y, colours[int(math.Ceil(float64(len(colours)-1)*v))])
+		}
+	}
+
+	return img, nil
+}
+
+// stft calculates the normalised magnitude spectra of the (centred, zero padded) frames of a
+// short-time Fourier transform, caching the most recently calculated frame.
+type stft struct {
+	samples []float32
+	N       int
+	hop     int
+	window  []float64
+	gain    float64
+	vscale  float64
+	buffer  []complex128
+	frame   int
+	cached  []float64
+}
+
+func newSTFT(samples []float32, N, hop int, window Window, vscale float64) *stft {
+	coefficients := window.coefficients(N)
+	sum := 0.0
+	for _, v := range coefficients {
+		sum += v
+	}
+
+	return &stft{
+		samples: samples,
+		N:       N,
+		hop:     hop,
+		window:  coefficients,
+		gain:    2.0 / sum,
+		vscale:  vscale,
+		buffer:  make([]complex128, N),
+		frame:   -1,
+		cached:  make([]float64, N/2+1),
+	}
+}
+
+func (s *stft) frames() int {
+	return 1 + (len(s.samples)-1)/s.hop
+}
+
+func (s *stft) spectrum(frame int) []float64 {
+	if frame == s.frame {
+		return s.cached
+	}
+
+	offset := frame*s.hop - s.N/2
+	for i := range s.buffer {
+		v := 0.0
+		if j := offset + i; j >= 0 && j < len(s.samples) {
+			v = float64(s.samples[j]) * s.vscale * s.window[i]
+		}
+
+		s.buffer[i] = complex(v, 0)
+	}
+
+	fft(s.buffer)
+
+	for i := range s.cached {
+		re := real(s.buffer[i])
+		im := imag(s.buffer[i])
+		s.cached[i] = math.Sqrt(re*re+im*im) * s.gain
+	}
+
+	s.frame = frame
+
+	return s.cached
+}
@@ -0,0 +1,107 @@
+package spectrogram
+
+import (
+	"image/color"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+)
+
+var black = color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+var white = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+func TestFFT(t *testing.T) {
+	N := 64
+	x := make([]complex128, N)
+	for i := range x {
+		x[i] = complex(math.Cos(2*math.Pi*5*float64(i)/float64(N)), 0)
+	}
+
+	if err := fft(x); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	for k, v := range x {
+		expected := 0.0
+		if k == 5 || k == N-5 {
+			expected = float64(N) / 2
+		}
+
+		if math.Abs(cmplx.Abs(v)-expected) > 1e-9 {
+			t.Errorf("incorrect magnitude for bin %v - expected:%v, got:%v", k, expected, cmplx.Abs(v))
+		}
+	}
+}
+
+func TestFFTWithInvalidSize(t *testing.T) {
+	if err := fft(make([]complex128, 48)); err == nil {
+		t.Errorf("expected error for FFT size that is not a power of 2")
+	}
+}
+
+func TestRender(t *testing.T) {
+	renderer := Spectrogram{
+		FFTSize:    512,
+		Window:     Hann,
+		Scale:      Linear,
+		SampleRate: 8000,
+		MinDB:      -60,
+		MaxDB:      0,
+		Palette:    palettes.NewPalette("bw", []color.NRGBA{black, white}),
+	}
+
+	samples := make([]float32, 8000)
+	for i := range samples {
+		samples[i] = float32(math.Sin(2 * math.Pi * 1000 * float64(i) / 8000))
+	}
+
+	img, err := renderer.Render(samples, 32, 101, 0, 1.0)
+	if err != nil {
+		t.Fatalf("error rendering test image (%v)", err)
+	}
+
+	// ... 1kHz is at 1/4 of the Nyquist frequency i.e. row 75 of 101 (skipping the zero padded end frames)
+	for x := 1; x < 31; x++ {
+		if colour := img.NRGBAAt(x, 75); colour.R < 0xf0 {
+			t.Errorf("incorrect colour at (%v,%v) - expected:%v, got:%v", x, 75, white, colour)
+		}
+
+		if colour := img.NRGBAAt(x, 25); colour.R > 0x10 {
+			t.Errorf("incorrect colour at (%v,%v) - expected:%v, got:%v", x, 25, black, colour)
+		}
+	}
+}
+
+func TestRenderWithInvalidFFTSize(t *testing.T) {
+	renderer := Spectrogram{
+		FFTSize: 1000,
+		Palette: palettes.Default,
+	}
+
+	if _, err := renderer.Render(make([]float32, 8000), 32, 32, 0, 1.0); err == nil {
+		t.Errorf("expected error rendering with invalid FFT size")
+	}
+}
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		scale    Scale
+		p        float64
+		expected float64
+	}{
+		{Linear, 0.5, 11025},
+		{Log, 0.0, 100},
+		{Log, 0.5, 1484.9},
+		{Log, 1.0, 22050},
+		{Mel, 0.0, 100},
+		{Mel, 1.0, 22050},
+	}
+
+	for _, v := range tests {
+		if f := v.scale.frequency(v.p, 100, 22050); math.Abs(f-v.expected) > 0.5 {
+			t.Errorf("incorrect frequency for %v scale at %v - expected:%v, got:%v", v.scale, v.p, v.expected, f)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package spectrogram
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Window int
+
+const (
+	Hann Window = iota
+	Hamming
+	Blackman
+	Rectangular
+)
+
+func ParseWindow(s string) (Window, error) {
+	switch strings.ToLower(s) {
+	case "hann", "hanning":
+		return Hann, nil
+	case "hamming":
+		return Hamming, nil
+	case "blackman":
+		return Blackman, nil
+	case "rectangular", "none":
+		return Rectangular, nil
+	}
+
+	return Hann, fmt.Errorf("invalid window function (%v)", s)
+}
+
+func (w Window) String() string {
+	return [...]string{"hann", "hamming", "blackman", "rectangular"}[w]
+}
+
+// coefficients returns the N window coefficients for the window function.
+func (w Window) coefficients(N int) []float64 {
+	coefficients := make([]float64, N)
+
+	for i := range coefficients {
+		t := 2 * math.Pi * float64(i) / float64(N-1)
+
+		switch w {
+		case Hann:
+			coefficients[i] = 0.5 - 0.5*math.Cos(t)
+		case Hamming:
+			coefficients[i] = 0.54 - 0.46*math.Cos(t)
+		case Blackman:
+			coefficients[i] = 0.42 - 0.5*math.Cos(t) + 0.08*math.Cos(2*t)
+		default:
+			coefficients[i] = 1.0
+		}
+	}
+
+	if N == 1 {
+		coefficients[0] = 1.0
+	}
+
+	return coefficients
+}
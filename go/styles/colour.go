@@ -17,3 +17,25 @@ func colour(s string) color.NRGBA {
 
 	return color.NRGBA{R: 0, G: 128, B: 0, A: 255}
 }
+
+// parseColour parses a #rrggbb or #rrggbbaa colour string.
+func parseColour(s string) (color.NRGBA, error) {
+	var red uint8
+	var green uint8
+	var blue uint8
+	var alpha uint8 = 0xff
+
+	switch len(s) {
+	case 7:
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &red, &green, &blue); err == nil {
+			return color.NRGBA{R: red, G: green, B: blue, A: alpha}, nil
+		}
+
+	case 9:
+		if _, err := fmt.Sscanf(s, "#%02x%02x%02x%02x", &red, &green, &blue, &alpha); err == nil {
+			return color.NRGBA{R: red, G: green, B: blue, A: alpha}, nil
+		}
+	}
+
+	return color.NRGBA{}, fmt.Errorf("invalid colour (%v)", s)
+}
@@ -1,34 +1,100 @@
 package styles
 
 import (
+	"encoding/json"
 	"fmt"
+	"image"
 	"image/color"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
+	_ "golang.org/x/image/webp"
+
 	"github.com/transcriptaze/wav2png/go/fills"
 )
 
+// Fill specifies the background fill, i.e. one of:
+//   - none:   transparent background
+//   - solid:  Colour (#rrggbb) and Alpha
+//   - linear: Colours (#rrggbbaa) spaced evenly along a gradient line at Angle degrees (defaults to 180, i.e. top to bottom)
+//   - radial: Colours (#rrggbbaa) from the centre to the corners
+//   - image:  a PNG, JPEG or WebP Image file, fitted to the background according to Mode (cover, stretch or tile)
 type Fill struct {
-	Fill   string `json:"fill"`
-	Colour string `json:"colour"`
-	Alpha  uint8  `json:"alpha"`
+	Fill    string   `json:"fill"`
+	Colour  string   `json:"colour"`
+	Alpha   uint8    `json:"alpha"`
+	Colours []string `json:"colours,omitempty"`
+	Angle   *float64 `json:"angle,omitempty"`
+	Image   string   `json:"image,omitempty"`
+	Mode    string   `json:"mode,omitempty"`
+
+	texture image.Image
 }
 
+const ANGLE = 180.0
+
 func (f Fill) String() string {
-	if f.Fill == "solid" {
+	switch f.Fill {
+	case "none":
+		return "none"
+
+	case "solid":
 		return fmt.Sprintf("%v:%v%02x", f.Fill, f.Colour, f.Alpha)
+
+	case "linear":
+		return fmt.Sprintf("%v:%v:%v", f.Fill, strings.Join(f.Colours, ":"), f.angle())
+
+	case "radial":
+		return fmt.Sprintf("%v:%v", f.Fill, strings.Join(f.Colours, ":"))
+
+	case "image":
+		if f.Mode != "" {
+			return fmt.Sprintf("%v:%v:%v", f.Fill, f.Image, f.Mode)
+		}
+
+		return fmt.Sprintf("%v:%v", f.Fill, f.Image)
 	}
 
 	return "??"
 }
 
+// Set parses a legacy fill specification, e.g.
+//
+//	none
+//	solid:#0000ffff
+//	linear:#000000ff:#000080ff[:...][:90]
+//	radial:#000080ff:#000000ff[:...]
+//	image:textures/old_map.png[:stretch|tile|cover]
+//
+// The image for an image fill is loaded relative to the working directory.
 func (f *Fill) Set(s string) error {
+	fill := *f
+
+	if err := fill.parse(s); err != nil {
+		return err
+	} else if err := fill.load(); err != nil {
+		return err
+	}
+
+	*f = fill
+
+	return nil
+}
+
+// parse parses a fill specification without loading the image for an image fill.
+func (f *Fill) parse(s string) error {
 	ss := strings.ToLower(s)
-	match := regexp.MustCompile("^(solid).*").FindStringSubmatch(ss)
+	match := regexp.MustCompile("^(none|solid|linear|radial|image).*").FindStringSubmatch(ss)
 
 	if len(match) > 1 {
 		switch match[1] {
+		case "none":
+			*f = Fill{Fill: "none"}
+
 		case "solid":
 			f.Fill = "solid"
 
@@ -38,13 +104,113 @@ func (f *Fill) Set(s string) error {
 				f.Colour = fmt.Sprintf("#%02x%02x%02x", color.R, color.G, color.B)
 				f.Alpha = color.A
 			}
+
+		case "linear", "radial":
+			fill := Fill{Fill: match[1]}
+			tokens := strings.Split(ss, ":")[1:]
+
+			if match[1] == "linear" && len(tokens) > 0 && !strings.HasPrefix(tokens[len(tokens)-1], "#") {
+				if angle, err := strconv.ParseFloat(tokens[len(tokens)-1], 64); err != nil {
+					return fmt.Errorf("invalid gradient angle (%v)", tokens[len(tokens)-1])
+				} else {
+					fill.Angle = &angle
+					tokens = tokens[:len(tokens)-1]
+				}
+			}
+
+			fill.Colours = tokens
+			if err := fill.validate(); err != nil {
+				return err
+			}
+
+			*f = fill
+
+		case "image":
+			match := regexp.MustCompile("^(?i:image):(.+?)(?::(?i:(stretch|tile|cover)))?$").FindStringSubmatch(s)
+			if len(match) < 3 {
+				return fmt.Errorf("invalid image fill (%v)", s)
+			}
+
+			fill := Fill{Fill: "image", Image: match[1], Mode: strings.ToLower(match[2])}
+
+			if err := fill.validate(); err != nil {
+				return err
+			}
+
+			*f = fill
+		}
+	}
+
+	return nil
+}
+
+// UnmarshalJSON unmarshals either a fill specification string (as for Set) or a fill object, merging
+// the object with the existing fill and accepting 'type' as a synonym for 'fill' e.g.
+//
+//	"none"
+//	"solid:#00000080"
+//	{ "type": "linear", "colours": [ "#000000ff", "#000080ff" ], "angle": 90 }
+//	{ "type": "image", "image": "textures/old_map.png", "mode": "tile" }
+//
+// The image for an image fill is not loaded until the image path is resolved (by Style.Load, relative
+// to the style file) or the fill is rendered.
+func (f *Fill) UnmarshalJSON(bytes []byte) error {
+	type serializable Fill
+
+	var spec string
+	if err := json.Unmarshal(bytes, &spec); err == nil {
+		if !regexp.MustCompile("^(none|solid|linear|radial|image)").MatchString(strings.ToLower(spec)) {
+			return fmt.Errorf("invalid fill (%v)", spec)
 		}
+
+		return f.parse(spec)
+	}
+
+	fill := serializable(*f)
+	t := struct {
+		Type string `json:"type"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &fill); err != nil {
+		return err
+	} else if err := json.Unmarshal(bytes, &t); err != nil {
+		return err
+	} else if t.Type != "" {
+		fill.Fill = strings.ToLower(t.Type)
 	}
 
+	v := Fill(fill)
+	v.texture = nil
+	if err := v.validate(); err != nil {
+		return err
+	}
+
+	*f = v
+
 	return nil
 }
 
 func (f *Fill) FillSpec() fills.FillSpec {
+	switch f.Fill {
+	case "none":
+		return fills.NewNoFill()
+
+	case "linear":
+		return fills.NewLinearGradientFill(f.colours(), f.angle())
+
+	case "radial":
+		return fills.NewRadialGradientFill(f.colours())
+
+	case "image":
+		mode, _ := fills.ParseImageMode(f.Mode)
+		texture := f.texture
+		if texture == nil {
+			texture, _ = loadImage(f.Image)
+		}
+
+		return fills.NewImageFill(texture, mode)
+	}
+
 	colour := color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0x00}
 
 	red := uint8(0)
@@ -57,3 +223,91 @@ func (f *Fill) FillSpec() fills.FillSpec {
 
 	return fills.NewSolidFill(colour)
 }
+
+// validate checks the gradient colours and image mode.
+func (f *Fill) validate() error {
+	switch f.Fill {
+	case "none", "solid", "":
+		return nil
+
+	case "linear", "radial":
+		if len(f.Colours) < 2 {
+			return fmt.Errorf("invalid gradient fill - expected at least 2 colours (%v)", len(f.Colours))
+		}
+
+		for _, s := range f.Colours {
+			if _, err := parseColour(s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case "image":
+		_, err := fills.ParseImageMode(f.Mode)
+
+		return err
+	}
+
+	return fmt.Errorf("invalid fill (%v)", f.Fill)
+}
+
+func (f Fill) colours() []color.NRGBA {
+	colours := []color.NRGBA{}
+	for _, s := range f.Colours {
+		if c, err := parseColour(s); err == nil {
+			colours = append(colours, c)
+		}
+	}
+
+	return colours
+}
+
+func (f Fill) angle() float64 {
+	if f.Angle != nil {
+		return *f.Angle
+	}
+
+	return ANGLE
+}
+
+// load loads the image for an image fill.
+func (f *Fill) load() error {
+	if f.Fill == "image" {
+		if img, err := loadImage(f.Image); err != nil {
+			return err
+		} else {
+			f.texture = img
+		}
+	}
+
+	return nil
+}
+
+// resolve resolves the path of an image fill unmarshalled from a style file against the style file
+// directory and loads the image.
+func (f *Fill) resolve(dir string) error {
+	if f.Fill == "image" && f.texture == nil {
+		f.Image = join(dir, f.Image)
+
+		return f.load()
+	}
+
+	return nil
+}
+
+// loadImage loads a PNG, JPEG or WebP image file.
+func loadImage(file string) (image.Image, error) {
+	r, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	defer r.Close()
+
+	if img, _, err := image.Decode(r); err != nil {
+		return nil, fmt.Errorf("invalid image file %v (%v)", file, err)
+	} else {
+		return img, nil
+	}
+}
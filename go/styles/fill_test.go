@@ -0,0 +1,129 @@
+package styles
+
+import (
+	"encoding/json"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFillSet(t *testing.T) {
+	texture := texture(t)
+	angle := 90.0
+
+	tests := []struct {
+		spec     string
+		expected Fill
+	}{
+		{"none", Fill{Fill: "none"}},
+		{"solid:#0000ff80", Fill{Fill: "solid", Colour: "#0000ff", Alpha: 0x80}},
+		{"linear:#000000ff:#000080ff", Fill{Fill: "linear", Colours: []string{"#000000ff", "#000080ff"}}},
+		{"linear:#000000ff:#000080ff:90", Fill{Fill: "linear", Colours: []string{"#000000ff", "#000080ff"}, Angle: &angle}},
+		{"radial:#000080ff:#000000ff", Fill{Fill: "radial", Colours: []string{"#000080ff", "#000000ff"}}},
+		{"image:" + texture, Fill{Fill: "image", Image: texture}},
+		{"image:" + texture + ":tile", Fill{Fill: "image", Image: texture, Mode: "tile"}},
+	}
+
+	for _, test := range tests {
+		fill := Fill{}
+		if err := fill.Set(test.spec); err != nil {
+			t.Errorf("error parsing fill %q (%v)", test.spec, err)
+			continue
+		}
+
+		fill.texture = nil
+		if !reflect.DeepEqual(fill, test.expected) {
+			t.Errorf("incorrectly parsed fill %q\n   expected:%+v\n   got:     %+v", test.spec, test.expected, fill)
+		}
+	}
+}
+
+func TestFillSetInvalid(t *testing.T) {
+	tests := []string{
+		"image",
+		"image:",
+		"imagefoo",
+		"image:no-such-file.png",
+		"linear:#000000ff",
+		"linear:#000000ff:#000080ff:sideways",
+	}
+
+	for _, spec := range tests {
+		fill := Fill{}
+		if err := fill.Set(spec); err == nil {
+			t.Errorf("expected error parsing invalid fill %q", spec)
+		}
+	}
+}
+
+func TestFillUnmarshalJSON(t *testing.T) {
+	texture := texture(t)
+	angle := 45.0
+
+	tests := []struct {
+		json     string
+		expected Fill
+	}{
+		{`"none"`, Fill{Fill: "none"}},
+		{`"solid:#00000080"`, Fill{Fill: "solid", Colour: "#000000", Alpha: 0x80}},
+		{`{ "type": "linear", "colours": [ "#000000ff", "#000080ff" ], "angle": 45 }`, Fill{Fill: "linear", Colours: []string{"#000000ff", "#000080ff"}, Angle: &angle}},
+		{`{ "fill": "radial", "colours": [ "#000080ff", "#000000ff" ] }`, Fill{Fill: "radial", Colours: []string{"#000080ff", "#000000ff"}}},
+		{`{ "type": "image", "image": "` + texture + `", "mode": "stretch" }`, Fill{Fill: "image", Image: texture, Mode: "stretch"}},
+	}
+
+	for _, test := range tests {
+		fill := Fill{}
+		if err := json.Unmarshal([]byte(test.json), &fill); err != nil {
+			t.Errorf("error unmarshalling fill %v (%v)", test.json, err)
+			continue
+		}
+
+		fill.texture = nil
+		if !reflect.DeepEqual(fill, test.expected) {
+			t.Errorf("incorrectly unmarshalled fill %v\n   expected:%+v\n   got:     %+v", test.json, test.expected, fill)
+		}
+	}
+}
+
+func TestFillUnmarshalJSONInvalid(t *testing.T) {
+	tests := []string{
+		`"image"`,
+		`"speckled"`,
+		`{ "type": "speckled" }`,
+		`{ "type": "linear", "colours": [ "#000000ff" ] }`,
+		`{ "type": "radial", "colours": [ "#000000ff", "blue" ] }`,
+		`{ "type": "image", "image": "texture.png", "mode": "sideways" }`,
+	}
+
+	for _, v := range tests {
+		fill := Fill{}
+		if err := json.Unmarshal([]byte(v), &fill); err == nil {
+			t.Errorf("expected error unmarshalling invalid fill %v", v)
+		}
+	}
+}
+
+// texture writes a small PNG image to a temporary directory and returns the file path.
+func texture(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "texture.png")
+
+	writePNG(t, file)
+
+	return file
+}
+
+func writePNG(t *testing.T, file string) {
+	f, err := os.Create(file)
+	if err != nil {
+		t.Fatalf("error creating test image (%v)", err)
+	}
+
+	defer f.Close()
+
+	if err := png.Encode(f, image.NewNRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("error encoding test image (%v)", err)
+	}
+}
@@ -0,0 +1,51 @@
+package styles
+
+import (
+	"encoding/json"
+	"image/color"
+
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+type gradient struct {
+	gradient *renderers.Gradient
+}
+
+// UnmarshalJSON unmarshals a vertical gradient with two or three colours and an optional midpoint
+// e.g. { "colours": [ "#80ccffff", "#ff8000ff" ], "midpoint": 0.5 }.
+func (g *gradient) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		Colours  []string `json:"colours"`
+		Midpoint *float64 `json:"midpoint"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	colours := make([]color.NRGBA, len(serializable.Colours))
+	for i, s := range serializable.Colours {
+		if c, err := parseColour(s); err != nil {
+			return err
+		} else {
+			colours[i] = c
+		}
+	}
+
+	midpoint := renderers.MIDPOINT
+	if serializable.Midpoint != nil {
+		midpoint = *serializable.Midpoint
+	}
+
+	if v, err := renderers.NewGradient(colours, midpoint); err != nil {
+		return err
+	} else {
+		g.gradient = v
+	}
+
+	return nil
+}
+
+func (g gradient) Gradient() *renderers.Gradient {
+	return g.gradient
+}
@@ -1,6 +1,7 @@
 package styles
 
 import (
+	"encoding/json"
 	"fmt"
 	"image/color"
 	"regexp"
@@ -11,12 +12,42 @@ import (
 )
 
 type Grid struct {
-	Grid    string `json:"grid"`
-	Colour  string `json:"colour"`
-	Alpha   uint8  `json:"alpha"`
-	Size    string `json:"size"`
-	WH      string `json:"wh"`
-	Overlay bool   `json:"overlay"`
+	Grid     string        `json:"grid"`
+	Colour   string        `json:"colour"`
+	Alpha    uint8         `json:"alpha"`
+	Size     string        `json:"size"`
+	WH       string        `json:"wh"`
+	Overlay  bool          `json:"overlay"`
+	Axis     string        `json:"axis,omitempty"`
+	Labels   bool          `json:"labels,omitempty"`
+	Width    uint          `json:"width,omitempty"`
+	Dash     []uint        `json:"dash,omitempty"`
+	Major    *GridLine     `json:"major,omitempty"`
+	Centre   *GridLine     `json:"centre,omitempty"`
+	Elements *GridElements `json:"elements,omitempty"`
+}
+
+// GridLine is the style of the major grid lines (drawn every Interval lines) or the centre line e.g.
+//
+//	"major":  { "colour": "#00ff00ff", "width": 2, "interval": 4 }
+//	"centre": { "colour": "#ffffff80", "dash": [ 4, 2 ] }
+type GridLine struct {
+	Colour   string `json:"colour"`
+	Width    uint   `json:"width,omitempty"`
+	Dash     []uint `json:"dash,omitempty"`
+	Interval uint   `json:"interval,omitempty"`
+}
+
+// GridElements independently enables the grid border, vertical lines, horizontal lines and centre line
+// and sets whether each is drawn 'under' or 'over' the waveform. 'none' hides the element and an
+// unspecified element follows the grid 'overlay' setting e.g.
+//
+//	"elements": { "border": "over", "vlines": "under", "hlines": "none", "centre": "over" }
+type GridElements struct {
+	Border string `json:"border,omitempty"`
+	VLines string `json:"vlines,omitempty"`
+	HLines string `json:"hlines,omitempty"`
+	Centre string `json:"centre,omitempty"`
 }
 
 func (g Grid) String() string {
@@ -40,12 +71,36 @@ func (g Grid) String() string {
 		}
 	}
 
+	if g.Grid == "timeline" || g.Grid == "db" {
+		spec := fmt.Sprintf("%v:%v%02x:%v", g.Grid, g.Colour, g.Alpha, g.Size)
+		if g.Labels {
+			spec += ":labels"
+		}
+
+		if g.Grid == "timeline" && g.Axis != "" && g.Axis != "none" {
+			spec += ":" + g.Axis
+		}
+
+		if g.Overlay {
+			spec += ":overlay"
+		}
+
+		return spec
+	}
+
 	return "??"
 }
 
+// Set parses a legacy grid specification, e.g.
+//
+//	none
+//	square:#008000ff:~64[:overlay]
+//	rectangular:#008000ff:~64x48[:overlay]
+//	timeline:#008000ff:~64[:labels][:amplitude|db][:overlay]
+//	db:#008000ff:~64[:labels][:overlay]
 func (g *Grid) Set(s string) error {
 	ss := strings.ToLower(s)
-	match := regexp.MustCompile("^(none|square|rectangular).*").FindStringSubmatch(ss)
+	match := regexp.MustCompile("^(none|square|rectangular|timeline|db).*").FindStringSubmatch(ss)
 
 	if len(match) > 1 {
 		switch match[1] {
@@ -94,13 +149,174 @@ func (g *Grid) Set(s string) error {
 				g.Overlay = true
 			}
 
+		case "timeline", "db":
+			g.Grid = match[1]
+			g.Labels = false
+			g.Axis = ""
+			g.Overlay = false
+
+			match = regexp.MustCompile("^(?:timeline|db):(#[[:xdigit:]]{8}).*").FindStringSubmatch(ss)
+			if len(match) > 1 {
+				color := colour(match[1])
+				g.Colour = fmt.Sprintf("#%02x%02x%02x", color.R, color.G, color.B)
+				g.Alpha = color.A
+			}
+
+			match = regexp.MustCompile("^(?:timeline|db):#[[:xdigit:]]{8}:([~=≥≤><]?[0-9]+).*").FindStringSubmatch(ss)
+			if len(match) > 1 {
+				fit, size := size(match[1])
+				g.Size = fmt.Sprintf("%v%v", fit, size)
+			}
+
+			for _, token := range strings.Split(ss, ":")[1:] {
+				switch token {
+				case "labels":
+					g.Labels = true
+				case "amplitude", "db":
+					if g.Grid == "timeline" {
+						g.Axis = token
+					}
+				case "overlay":
+					g.Overlay = true
+				}
+			}
+
 		}
 	}
 
 	return nil
 }
 
+// UnmarshalJSON unmarshals either a grid specification string (as for Set) or a grid object, merging
+// the object with the existing grid e.g.
+//
+//	"none"
+//	{ "grid": "rectangular", "colour": "#800000ff", "wh": "~64x64", "overlay": true }
+func (g *Grid) UnmarshalJSON(bytes []byte) error {
+	type serializable Grid
+
+	var spec string
+	if err := json.Unmarshal(bytes, &spec); err == nil {
+		if !regexp.MustCompile("^(none|square|rectangular|timeline|db)").MatchString(strings.ToLower(spec)) {
+			return fmt.Errorf("invalid grid (%v)", spec)
+		}
+
+		return g.Set(spec)
+	}
+
+	grid := serializable(*g)
+
+	if err := json.Unmarshal(bytes, &grid); err != nil {
+		return err
+	}
+
+	if _, err := grids.ParseYAxis(grid.Axis); err != nil {
+		return err
+	}
+
+	for _, line := range []*GridLine{grid.Major, grid.Centre} {
+		if line != nil {
+			if _, err := parseColour(line.Colour); err != nil {
+				return err
+			}
+		}
+	}
+
+	if grid.Elements != nil {
+		if _, err := grid.Elements.elements(); err != nil {
+			return err
+		}
+	}
+
+	switch grid.Grid {
+	case "none", "square", "rectangular", "timeline", "db":
+		*g = Grid(grid)
+		return nil
+	}
+
+	return fmt.Errorf("invalid grid (%v)", grid.Grid)
+}
+
 func (g Grid) GridSpec() grids.GridSpec {
+	spec := g.gridspec()
+
+	if g.Elements != nil {
+		if elements, err := g.Elements.elements(); err == nil {
+			spec = grids.WithElements(spec, elements)
+		}
+	}
+
+	if g.Width > 0 || len(g.Dash) > 0 || g.Major != nil || g.Centre != nil {
+		minor := grids.LineStyle{
+			Colour: spec.Colour(),
+			Width:  max(1, g.Width),
+			Dash:   g.Dash,
+		}
+
+		style := grids.GridStyle{
+			Minor: minor,
+			Major: minor,
+		}
+
+		if g.Major != nil {
+			style.Major = g.Major.style(minor)
+			style.Interval = g.Major.Interval
+		}
+
+		if g.Centre != nil {
+			centre := g.Centre.style(minor)
+			style.Centre = &centre
+		}
+
+		return grids.WithStyle(spec, style)
+	}
+
+	return spec
+}
+
+func (e GridElements) elements() (grids.Elements, error) {
+	elements := grids.Elements{}
+	layers := []struct {
+		layer *grids.Layer
+		value string
+	}{
+		{&elements.Border, e.Border},
+		{&elements.VLines, e.VLines},
+		{&elements.HLines, e.HLines},
+		{&elements.Centre, e.Centre},
+	}
+
+	for _, v := range layers {
+		if layer, err := grids.ParseLayer(v.value); err != nil {
+			return elements, err
+		} else {
+			*v.layer = layer
+		}
+	}
+
+	return elements, nil
+}
+
+// style returns the line style, defaulting the width to that of the minor grid lines.
+func (l GridLine) style(minor grids.LineStyle) grids.LineStyle {
+	style := grids.LineStyle{
+		Colour: minor.Colour,
+		Width:  minor.Width,
+		Dash:   l.Dash,
+	}
+
+	if c, err := parseColour(l.Colour); err == nil {
+		style.Colour = c
+	}
+
+	if l.Width > 0 {
+		style.Width = l.Width
+	}
+
+	return style
+}
+
+func (g Grid) gridspec() grids.GridSpec {
 	// ... overlay
 	overlay := g.Overlay
 
@@ -155,6 +371,21 @@ func (g Grid) GridSpec() grids.GridSpec {
 		return grids.NewRectangularGrid(colour, width, height, fit, overlay)
 	}
 
+	// ... dB
+	if g.Grid == "db" {
+		fit, spacing := size(g.Size)
+
+		return grids.NewDBGrid(colour, spacing, fit, g.Labels, overlay)
+	}
+
+	// ... timeline
+	if g.Grid == "timeline" {
+		fit, spacing := size(g.Size)
+		yaxis, _ := grids.ParseYAxis(g.Axis)
+
+		return grids.NewTimelineGrid(colour, spacing, fit, yaxis, g.Labels, overlay)
+	}
+
 	// ... default to square
 	fit := grids.Approximate
 	size := uint(64)
@@ -0,0 +1,38 @@
+package styles
+
+import (
+	"image"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/transcriptaze/wav2png/go/grids"
+)
+
+func TestGridSpecWithFit(t *testing.T) {
+	bounds := image.Rect(0, 0, 641, 386)
+
+	tests := []struct {
+		spec     string
+		expected []int
+	}{
+		{"timeline:#008000ff:~100", []int{128, 256, 384, 512}},
+		{"timeline:#008000ff:≤100", []int{64, 128, 192, 256, 320, 384, 448, 512, 576}},
+		{"timeline:#008000ff:>128", []int{320}},
+		{"db:#008000ff:~100", []int{107, 213, 320, 427, 533}},
+		{"db:#008000ff:=100", []int{100, 200, 300, 400, 500, 600}},
+		{"db:#008000ff:<100", []int{99, 198, 297, 396, 495, 594}},
+	}
+
+	for _, test := range tests {
+		grid := Grid{}
+		if err := grid.Set(test.spec); err != nil {
+			t.Fatalf("error parsing grid %q (%v)", test.spec, err)
+		}
+
+		spec := grids.WithTimeRange(grid.GridSpec(), 0, 10*time.Second)
+		if vlines := spec.VLines(bounds, 0); !reflect.DeepEqual(vlines, test.expected) {
+			t.Errorf("incorrect vertical lines for grid %q\n   expected:%v\n   got:     %v", test.spec, test.expected, vlines)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package styles
+
+import (
+	"encoding/json"
+	"image/color"
+
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+)
+
+type horizontal struct {
+	horizontal *renderers.Horizontal
+	played     *palette
+}
+
+// UnmarshalJSON unmarshals a horizontal colouring with an optional 'played' palette for the waveform
+// to the left of the playhead and an optional left to right gradient e.g.
+//
+//	{ "played": "fire", "playhead": 0.5, "gradient": [ "#ff8000ff", "#80ccffff" ] }
+func (h *horizontal) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		Played   json.RawMessage `json:"played"`
+		Playhead float64         `json:"playhead"`
+		Gradient []string        `json:"gradient"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	var p *palette
+	var played *palettes.Palette
+	if serializable.Played != nil {
+		p = &palette{}
+		if err := json.Unmarshal(serializable.Played, p); err != nil {
+			return err
+		}
+
+		v := p.Palette()
+		played = &v
+	}
+
+	colours := make([]color.NRGBA, len(serializable.Gradient))
+	for i, s := range serializable.Gradient {
+		if c, err := parseColour(s); err != nil {
+			return err
+		} else {
+			colours[i] = c
+		}
+	}
+
+	if v, err := renderers.NewHorizontal(played, serializable.Playhead, colours); err != nil {
+		return err
+	} else {
+		h.horizontal = v
+		h.played = p
+	}
+
+	return nil
+}
+
+// resolve loads the 'played' palette if it is a PNG palette file.
+func (h *horizontal) resolve(dir string) error {
+	if h.played != nil && h.horizontal != nil {
+		if err := h.played.resolve(dir); err != nil {
+			return err
+		}
+
+		v := h.played.Palette()
+		h.horizontal.Played = &v
+	}
+
+	return nil
+}
+
+func (h horizontal) Horizontal() *renderers.Horizontal {
+	return h.horizontal
+}
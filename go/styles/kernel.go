@@ -11,6 +11,14 @@ type kernel struct {
 	kernel kernels.Kernel
 }
 
+// UnmarshalJSON accepts either the name of one of the predefined kernels or a kernel object with
+// one of:
+//   - "gaussian": sigma
+//   - "box": size
+//   - "kernel": N×N matrix
+//   - "horizontal" and "vertical": separable 1D kernels
+//
+// and an optional "edge" mode ("clamp" or "mirror").
 func (k *kernel) UnmarshalJSON(bytes []byte) error {
 	var s string
 
@@ -35,7 +43,61 @@ func (k *kernel) UnmarshalJSON(bytes []byte) error {
 		return nil
 	}
 
-	return fmt.Errorf("invalid kernel spec")
+	serializable := struct {
+		Gaussian   *float64    `json:"gaussian"`
+		Box        *int        `json:"box"`
+		Kernel     [][]float64 `json:"kernel"`
+		Horizontal []float64   `json:"horizontal"`
+		Vertical   []float64   `json:"vertical"`
+		Edge       string      `json:"edge"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return fmt.Errorf("invalid kernel spec")
+	}
+
+	var kernel kernels.Kernel
+	var err error
+
+	switch {
+	case serializable.Gaussian != nil:
+		kernel, err = kernels.Gaussian(*serializable.Gaussian)
+
+	case serializable.Box != nil:
+		kernel, err = kernels.Box(*serializable.Box)
+
+	case serializable.Kernel != nil:
+		kernel, err = kernels.NewKernel(serializable.Kernel)
+
+	case serializable.Horizontal != nil || serializable.Vertical != nil:
+		horizontal := serializable.Horizontal
+		vertical := serializable.Vertical
+
+		if horizontal == nil {
+			horizontal = []float64{1}
+		}
+
+		if vertical == nil {
+			vertical = []float64{1}
+		}
+
+		kernel, err = kernels.NewSeparable(horizontal, vertical)
+
+	default:
+		return fmt.Errorf("invalid kernel spec")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if edge, err := kernels.ParseEdge(serializable.Edge); err != nil {
+		return err
+	} else {
+		k.kernel = kernel.WithEdge(edge)
+	}
+
+	return nil
 }
 
 func (k kernel) Kernel() kernels.Kernel {
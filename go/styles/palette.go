@@ -1,21 +1,38 @@
 package styles
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/transcriptaze/wav2png/go/palettes"
 )
 
 type palette struct {
 	palette palettes.Palette
+	file    string
 }
 
+// UnmarshalJSON accepts a palette specified as one of:
+//   - the name of an internal palette e.g. "ice"
+//   - the path to a PNG file e.g. "amber.png", loaded when the path is resolved by Style.Load
+//   - a list of colours e.g. [ "#00000000", "#80ccffff" ]
+//   - a gradient e.g. { "stops": [ { "offset": 0, "colour": "#000000" }, ... ], "interpolation": "oklab" }
+//
+// Gradients may optionally include an alpha ramp e.g. "alpha": [ { "offset": 0, "alpha": 0 }, { "offset": 0.1, "alpha": 1 } ].
 func (p *palette) UnmarshalJSON(bytes []byte) error {
 	var s string
+	var list []string
 
 	if err := json.Unmarshal(bytes, &s); err == nil {
 		switch s {
+		case "default":
+			p.palette = palettes.Default
 		case "ice":
 			p.palette = palettes.Ice
 		case "fire":
@@ -32,16 +49,123 @@ func (p *palette) UnmarshalJSON(bytes []byte) error {
 			p.palette = palettes.Green
 		case "gold":
 			p.palette = palettes.Gold
+		case "viridis":
+			p.palette = palettes.Viridis
+		case "magma":
+			p.palette = palettes.Magma
+		case "inferno":
+			p.palette = palettes.Inferno
+		case "cividis":
+			p.palette = palettes.Cividis
 		default:
-			p.palette = palettes.Default
+			if !strings.EqualFold(filepath.Ext(s), ".png") {
+				return fmt.Errorf("invalid palette (%v)", s)
+			} else {
+				p.file = s
+			}
+		}
+
+		return nil
+	}
+
+	if err := json.Unmarshal(bytes, &list); err == nil {
+		if len(list) == 0 {
+			return fmt.Errorf("invalid palette - no colours")
+		}
+
+		colours := make([]color.NRGBA, len(list))
+		for i, s := range list {
+			if c, err := parseColour(s); err != nil {
+				return err
+			} else {
+				colours[i] = c
+			}
 		}
 
+		p.palette = palettes.NewPalette("custom", colours)
+
 		return nil
 	}
 
-	return fmt.Errorf("invalid palette spec")
+	gradient := struct {
+		Stops []struct {
+			Offset float64 `json:"offset"`
+			Colour string  `json:"colour"`
+		} `json:"stops"`
+		Alpha []struct {
+			Offset float64 `json:"offset"`
+			Alpha  float64 `json:"alpha"`
+		} `json:"alpha"`
+		Interpolation string `json:"interpolation"`
+		Size          int    `json:"size"`
+	}{
+		Size: palettes.GRADIENT_SIZE,
+	}
+
+	if err := json.Unmarshal(bytes, &gradient); err != nil {
+		return fmt.Errorf("invalid palette spec")
+	}
+
+	stops := make([]palettes.Stop, len(gradient.Stops))
+	for i, stop := range gradient.Stops {
+		if c, err := parseColour(stop.Colour); err != nil {
+			return err
+		} else {
+			stops[i] = palettes.Stop{Offset: stop.Offset, Colour: c}
+		}
+	}
+
+	alpha := make([]palettes.AlphaStop, len(gradient.Alpha))
+	for i, stop := range gradient.Alpha {
+		alpha[i] = palettes.AlphaStop{Offset: stop.Offset, Alpha: stop.Alpha}
+	}
+
+	if interpolation, err := palettes.ParseInterpolation(gradient.Interpolation); err != nil {
+		return err
+	} else {
+		builder := palettes.Builder{
+			Stops:         stops,
+			Alpha:         alpha,
+			Interpolation: interpolation,
+			Size:          gradient.Size,
+		}
+
+		if palette, err := builder.Build("gradient"); err != nil {
+			return err
+		} else {
+			p.palette = palette
+		}
+	}
+
+	return nil
 }
 
 func (p palette) Palette() palettes.Palette {
 	return p.palette
 }
+
+// resolve loads a PNG palette file, resolving a relative path against the style file directory.
+func (p *palette) resolve(dir string) error {
+	if p.file != "" {
+		if palette, err := load(join(dir, p.file)); err != nil {
+			return err
+		} else {
+			p.palette = palette
+		}
+	}
+
+	return nil
+}
+
+// load creates a palette from the first column of a PNG file.
+func load(file string) (palettes.Palette, error) {
+	if b, err := os.ReadFile(file); err != nil {
+		return palettes.Palette{}, err
+	} else if img, err := png.Decode(bytes.NewBuffer(b)); err != nil {
+		return palettes.Palette{}, fmt.Errorf("invalid palette file %v (%v)", file, err)
+	} else if palette, err := palettes.PaletteFromPng(filepath.Base(file), img); err != nil {
+		return palettes.Palette{}, err
+	} else {
+		return *palette, nil
+	}
+}
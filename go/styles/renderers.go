@@ -2,42 +2,136 @@ package styles
 
 import (
 	"encoding/json"
+	"fmt"
+	"image/color"
+
+	"github.com/transcriptaze/wav2png/go/kernels"
+	"github.com/transcriptaze/wav2png/go/palettes"
+	"github.com/transcriptaze/wav2png/go/renderers"
+	"github.com/transcriptaze/wav2png/go/renderers/columns"
+	"github.com/transcriptaze/wav2png/go/renderers/lines"
+	"github.com/transcriptaze/wav2png/go/renderers/spectrogram"
 )
 
 type linesRenderer struct {
+	palette    palette
+	antialias  kernel
+	layout     renderers.Layout
+	mode       lines.Mode
+	dotSize    float64
+	gradient   gradient
+	horizontal horizontal
+}
+
+type columnsRenderer struct {
+	barWidth   uint
+	barGap     uint
+	radius     uint
+	minHeight  uint
+	colouring  columns.Colouring
+	palette    palette
+	antialias  kernel
+	layout     renderers.Layout
+	gradient   gradient
+	horizontal horizontal
+}
+
+type envelopeRenderer struct {
 	palette   palette
+	mirror    bool
 	antialias kernel
 }
 
-type columnsRenderer struct {
-	barWidth  uint
-	barGap    uint
+type spectrogramRenderer struct {
+	fftSize    int
+	hop        int
+	window     spectrogram.Window
+	scale      spectrogram.Scale
+	sampleRate float64
+	minDB      float64
+	maxDB      float64
+	palette    palette
+}
+
+type spectralRenderer struct {
+	sampleRate float64
+	low        float64
+	high       float64
+	antialias  kernel
+	layout     renderers.Layout
+}
+
+type radialRenderer struct {
+	radius    float64
+	mirror    bool
 	palette   palette
 	antialias kernel
 }
 
+type rmsRenderer struct {
+	peak      palette
+	rms       palette
+	antialias kernel
+}
+
 func (l *linesRenderer) UnmarshalJSON(bytes []byte) error {
 	serializable := struct {
-		Palette   json.RawMessage `json:"palette"`
-		Antialias json.RawMessage `json:"antialias"`
+		Palette    json.RawMessage `json:"palette"`
+		Antialias  json.RawMessage `json:"antialias"`
+		Layout     string          `json:"layout"`
+		Mode       string          `json:"mode"`
+		Dot        float64         `json:"dot"`
+		Gradient   json.RawMessage `json:"gradient"`
+		Horizontal json.RawMessage `json:"horizontal"`
 	}{}
 
 	if err := json.Unmarshal(bytes, &serializable); err != nil {
 		return err
 	} else {
-		palette := palette{}
+		palette := palette{palette: palettes.Default}
 		kernel := kernel{}
+		gradient := gradient{}
+		horizontal := horizontal{}
 
-		if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
-			return err
+		if serializable.Palette != nil {
+			if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
+				return err
+			}
+		}
+
+		if serializable.Gradient != nil {
+			if err := json.Unmarshal(serializable.Gradient, &gradient); err != nil {
+				return err
+			}
+		}
+
+		if serializable.Horizontal != nil {
+			if err := json.Unmarshal(serializable.Horizontal, &horizontal); err != nil {
+				return err
+			}
 		}
 
 		if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
 			return err
 		}
 
+		if layout, err := renderers.ParseLayout(serializable.Layout); err != nil {
+			return err
+		} else {
+			l.layout = layout
+		}
+
+		if mode, err := lines.ParseMode(serializable.Mode); err != nil {
+			return err
+		} else {
+			l.mode = mode
+			l.dotSize = serializable.Dot
+		}
+
 		l.palette = palette
 		l.antialias = kernel
+		l.gradient = gradient
+		l.horizontal = horizontal
 	}
 
 	return nil
@@ -46,32 +140,338 @@ func (l *linesRenderer) UnmarshalJSON(bytes []byte) error {
 func (c *columnsRenderer) UnmarshalJSON(bytes []byte) error {
 	serializable := struct {
 		Bar struct {
-			Width uint `json:"width"`
-			Gap   uint `json:"gap"`
+			Width     uint   `json:"width"`
+			Gap       uint   `json:"gap"`
+			Radius    uint   `json:"radius"`
+			MinHeight uint   `json:"min-height"`
+			Colour    string `json:"colour"`
 		} `json:"bar"`
-		Palette   json.RawMessage `json:"palette"`
-		Antialias json.RawMessage `json:"antialias"`
+		Palette    json.RawMessage `json:"palette"`
+		Antialias  json.RawMessage `json:"antialias"`
+		Layout     string          `json:"layout"`
+		Gradient   json.RawMessage `json:"gradient"`
+		Horizontal json.RawMessage `json:"horizontal"`
 	}{}
 
 	if err := json.Unmarshal(bytes, &serializable); err != nil {
 		return err
 	} else {
-		palette := palette{}
+		palette := palette{palette: palettes.Default}
 		kernel := kernel{}
+		gradient := gradient{}
+		horizontal := horizontal{}
 
-		if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
-			return err
+		if serializable.Palette != nil {
+			if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
+				return err
+			}
+		}
+
+		if serializable.Gradient != nil {
+			if err := json.Unmarshal(serializable.Gradient, &gradient); err != nil {
+				return err
+			}
+		}
+
+		if serializable.Horizontal != nil {
+			if err := json.Unmarshal(serializable.Horizontal, &horizontal); err != nil {
+				return err
+			}
 		}
 
 		if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
 			return err
 		}
 
+		if layout, err := renderers.ParseLayout(serializable.Layout); err != nil {
+			return err
+		} else {
+			c.layout = layout
+		}
+
+		if colouring, err := columns.ParseColouring(serializable.Bar.Colour); err != nil {
+			return err
+		} else {
+			c.colouring = colouring
+		}
+
 		c.barWidth = serializable.Bar.Width
 		c.barGap = serializable.Bar.Gap
+		c.radius = serializable.Bar.Radius
+		c.minHeight = serializable.Bar.MinHeight
 		c.palette = palette
 		c.antialias = kernel
+		c.gradient = gradient
+		c.horizontal = horizontal
+	}
+
+	return nil
+}
+
+func (r *rmsRenderer) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		Peak      json.RawMessage `json:"peak"`
+		RMS       json.RawMessage `json:"rms"`
+		Antialias json.RawMessage `json:"antialias"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	} else {
+		peak := palette{palette: palettes.Default}
+		rms := palette{palette: palettes.Ice}
+		kernel := kernel{kernel: kernels.Vertical}
+
+		if serializable.Peak != nil {
+			if err := unmarshalLayer(serializable.Peak, &peak); err != nil {
+				return err
+			}
+		}
+
+		if serializable.RMS != nil {
+			if err := unmarshalLayer(serializable.RMS, &rms); err != nil {
+				return err
+			}
+		}
+
+		if serializable.Antialias != nil {
+			if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
+				return err
+			}
+		}
+
+		r.peak = peak
+		r.rms = rms
+		r.antialias = kernel
+	}
+
+	return nil
+}
+
+func (e *envelopeRenderer) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		Mirror    bool            `json:"mirror"`
+		Antialias json.RawMessage `json:"antialias"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	} else {
+		palette := palette{palette: palettes.Default}
+		kernel := kernel{kernel: kernels.Vertical}
+
+		if err := unmarshalLayer(bytes, &palette); err != nil {
+			return err
+		}
+
+		if serializable.Antialias != nil {
+			if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
+				return err
+			}
+		}
+
+		e.palette = palette
+		e.mirror = serializable.Mirror
+		e.antialias = kernel
+	}
+
+	return nil
+}
+
+func (r *spectrogramRenderer) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		FFT        int             `json:"fft"`
+		Hop        int             `json:"hop"`
+		Window     string          `json:"window"`
+		Scale      string          `json:"scale"`
+		SampleRate float64         `json:"samplerate"`
+		Palette    json.RawMessage `json:"palette"`
+		DB         struct {
+			Min float64 `json:"min"`
+			Max float64 `json:"max"`
+		} `json:"dB"`
+	}{
+		FFT:    spectrogram.FFT_SIZE,
+		Window: "hann",
+		Scale:  "linear",
+	}
+
+	serializable.DB.Min = spectrogram.MIN_DB
+	serializable.DB.Max = spectrogram.MAX_DB
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	palette := palette{palette: palettes.Fire}
+	if serializable.Palette != nil {
+		if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
+			return err
+		}
+	}
+
+	if window, err := spectrogram.ParseWindow(serializable.Window); err != nil {
+		return err
+	} else if scale, err := spectrogram.ParseScale(serializable.Scale); err != nil {
+		return err
+	} else {
+		r.fftSize = serializable.FFT
+		r.hop = serializable.Hop
+		r.window = window
+		r.scale = scale
+		r.sampleRate = serializable.SampleRate
+		r.minDB = serializable.DB.Min
+		r.maxDB = serializable.DB.Max
+		r.palette = palette
 	}
 
 	return nil
 }
+
+func (r *spectralRenderer) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		SampleRate float64 `json:"samplerate"`
+		Crossover  struct {
+			Low  float64 `json:"low"`
+			High float64 `json:"high"`
+		} `json:"crossover"`
+		Antialias json.RawMessage `json:"antialias"`
+		Layout    string          `json:"layout"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	layout, err := renderers.ParseLayout(serializable.Layout)
+	if err != nil {
+		return err
+	}
+
+	if serializable.Crossover.Low < 0 || serializable.Crossover.High < 0 || (serializable.Crossover.High > 0 && serializable.Crossover.High < serializable.Crossover.Low) {
+		return fmt.Errorf("invalid crossover frequencies (%v,%v)", serializable.Crossover.Low, serializable.Crossover.High)
+	}
+
+	kernel := kernel{kernel: kernels.Vertical}
+	if serializable.Antialias != nil {
+		if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
+			return err
+		}
+	}
+
+	r.sampleRate = serializable.SampleRate
+	r.low = serializable.Crossover.Low
+	r.high = serializable.Crossover.High
+	r.antialias = kernel
+	r.layout = layout
+
+	return nil
+}
+
+func (r *radialRenderer) UnmarshalJSON(bytes []byte) error {
+	serializable := struct {
+		Radius    float64         `json:"radius"`
+		Mirror    bool            `json:"mirror"`
+		Palette   json.RawMessage `json:"palette"`
+		Antialias json.RawMessage `json:"antialias"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	if serializable.Radius < 0 || serializable.Radius > 1 {
+		return fmt.Errorf("invalid radial base radius (%v)", serializable.Radius)
+	}
+
+	palette := palette{palette: palettes.Default}
+	kernel := kernel{kernel: kernels.Soft}
+
+	if serializable.Palette != nil {
+		if err := json.Unmarshal(serializable.Palette, &palette); err != nil {
+			return err
+		}
+	}
+
+	if serializable.Antialias != nil {
+		if err := json.Unmarshal(serializable.Antialias, &kernel); err != nil {
+			return err
+		}
+	}
+
+	r.radius = serializable.Radius
+	r.mirror = serializable.Mirror
+	r.palette = palette
+	r.antialias = kernel
+
+	return nil
+}
+
+// unmarshalLayer unmarshals a renderer 'layer' coloured with either a palette or a single colour e.g.
+// { "palette": "ice" } or { "colour": "#80ccffff" }.
+func unmarshalLayer(bytes []byte, p *palette) error {
+	serializable := struct {
+		Palette json.RawMessage `json:"palette"`
+		Colour  *string         `json:"colour"`
+	}{}
+
+	if err := json.Unmarshal(bytes, &serializable); err != nil {
+		return err
+	}
+
+	if serializable.Palette != nil {
+		return json.Unmarshal(serializable.Palette, p)
+	}
+
+	if serializable.Colour != nil {
+		if c, err := parseColour(*serializable.Colour); err != nil {
+			return fmt.Errorf("invalid layer colour (%v)", *serializable.Colour)
+		} else {
+			p.palette = palettes.NewPalette(*serializable.Colour, []color.NRGBA{c})
+		}
+	}
+
+	return nil
+}
+
+// resolver is implemented by the renderers with palettes that may be loaded from a PNG file, to
+// resolve the palette file paths against the style file directory.
+type resolver interface {
+	resolve(dir string) error
+}
+
+func (l *linesRenderer) resolve(dir string) error {
+	if err := l.palette.resolve(dir); err != nil {
+		return err
+	}
+
+	return l.horizontal.resolve(dir)
+}
+
+func (c *columnsRenderer) resolve(dir string) error {
+	if err := c.palette.resolve(dir); err != nil {
+		return err
+	}
+
+	return c.horizontal.resolve(dir)
+}
+
+func (r *rmsRenderer) resolve(dir string) error {
+	if err := r.peak.resolve(dir); err != nil {
+		return err
+	}
+
+	return r.rms.resolve(dir)
+}
+
+func (e *envelopeRenderer) resolve(dir string) error {
+	return e.palette.resolve(dir)
+}
+
+func (r *spectrogramRenderer) resolve(dir string) error {
+	return r.palette.resolve(dir)
+}
+
+func (r *radialRenderer) resolve(dir string) error {
+	return r.palette.resolve(dir)
+}
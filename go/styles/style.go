@@ -2,8 +2,10 @@ package styles
 
 import (
 	"encoding/json"
+	"fmt"
 	"image/color"
 	"os"
+	"path/filepath"
 
 	"github.com/transcriptaze/wav2png/go/fills"
 	"github.com/transcriptaze/wav2png/go/grids"
@@ -11,21 +13,29 @@ import (
 	"github.com/transcriptaze/wav2png/go/palettes"
 	"github.com/transcriptaze/wav2png/go/renderers"
 	"github.com/transcriptaze/wav2png/go/renderers/columns"
+	"github.com/transcriptaze/wav2png/go/renderers/envelope"
 	"github.com/transcriptaze/wav2png/go/renderers/lines"
+	"github.com/transcriptaze/wav2png/go/renderers/radial"
+	"github.com/transcriptaze/wav2png/go/renderers/rms"
+	"github.com/transcriptaze/wav2png/go/renderers/spectrogram"
 )
 
 var BLACK = color.NRGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
 var GREEN = color.NRGBA{R: 0x00, G: 0x80, B: 0x00, A: 0xff}
 
+// MAX_SUPERSAMPLE is the maximum supersampling factor, limiting the memory used for large images.
+const MAX_SUPERSAMPLE = 8
+
 type Style struct {
-	name     string
-	width    uint
-	height   uint
-	padding  int
-	scale    Scale
-	fill     Fill
-	grid     Grid
-	renderer any
+	name        string
+	width       uint
+	height      uint
+	padding     int
+	scale       Scale
+	supersample uint
+	fill        Fill
+	grid        Grid
+	renderer    any
 }
 
 func NewStyle() Style {
@@ -35,6 +45,8 @@ func NewStyle() Style {
 		height:  600,
 		padding: 2,
 
+		supersample: 1,
+
 		scale: Scale{
 			Horizontal: 1.0,
 			Vertical:   1.0,
@@ -95,6 +107,42 @@ func (s Style) WithFill(fill Fill) Style {
 	return s
 }
 
+// WithSampleRate sets the sample rate of the audio for renderers that depend on it (e.g. the
+// spectrogram and spectral renderers) unless the style explicitly specifies a sample rate.
+func (s Style) WithSampleRate(fs float64) Style {
+	if r, ok := s.renderer.(*spectrogramRenderer); ok && r.sampleRate == 0 {
+		renderer := *r
+		renderer.sampleRate = fs
+		s.renderer = &renderer
+	}
+
+	if r, ok := s.renderer.(*spectralRenderer); ok && r.sampleRate == 0 {
+		renderer := *r
+		renderer.sampleRate = fs
+		s.renderer = &renderer
+	}
+
+	return s
+}
+
+// WithPlayhead sets the playhead position (as a fraction of the waveform width) for renderers with
+// a horizontal 'played' colouring.
+func (s Style) WithPlayhead(playhead float64) Style {
+	if r, ok := s.renderer.(*linesRenderer); ok && r.horizontal.horizontal != nil {
+		renderer := *r
+		renderer.horizontal.horizontal = r.horizontal.horizontal.WithPlayhead(playhead)
+		s.renderer = &renderer
+	}
+
+	if r, ok := s.renderer.(*columnsRenderer); ok && r.horizontal.horizontal != nil {
+		renderer := *r
+		renderer.horizontal.horizontal = r.horizontal.horizontal.WithPlayhead(playhead)
+		s.renderer = &renderer
+	}
+
+	return s
+}
+
 func (s Style) WithGrid(grid Grid) Style {
 	s.grid = grid
 
@@ -121,28 +169,116 @@ func (s Style) Scale() Scale {
 	return s.scale
 }
 
+// Supersample returns the factor by which the waveform and grid are oversampled before downsampling
+// to the image size.
+func (s Style) Supersample() uint {
+	return s.supersample
+}
+
 func (s Style) Fill() fills.FillSpec {
 	return s.fill.FillSpec()
 }
 
 func (s Style) Grid() grids.GridSpec {
-	return s.grid.GridSpec()
+	switch s.layout() {
+	case renderers.Top:
+		return grids.WithBaseline(s.grid.GridSpec(), grids.Bottom)
+
+	case renderers.Bottom:
+		return grids.WithBaseline(s.grid.GridSpec(), grids.Top)
+
+	default:
+		return s.grid.GridSpec()
+	}
+}
+
+// layout returns the waveform layout of the style renderer.
+func (s Style) layout() renderers.Layout {
+	switch r := s.renderer.(type) {
+	case *linesRenderer:
+		return r.layout
+	case *columnsRenderer:
+		return r.layout
+	case *spectralRenderer:
+		return r.layout
+	}
+
+	return renderers.Bipolar
 }
 
 func (s Style) Renderer() renderers.Renderer {
 	if r, ok := s.renderer.(*linesRenderer); ok {
 		return lines.Lines{
-			Palette:   r.palette.Palette(),
-			AntiAlias: kernels.Vertical,
+			Palette:    r.palette.Palette(),
+			AntiAlias:  r.antialias.Kernel(),
+			Layout:     r.layout,
+			Mode:       r.mode,
+			DotSize:    r.dotSize,
+			Gradient:   r.gradient.Gradient(),
+			Horizontal: r.horizontal.Horizontal(),
 		}
 	}
 
 	if r, ok := s.renderer.(*columnsRenderer); ok {
 		return columns.Columns{
-			BarWidth:  r.barWidth,
-			BarGap:    r.barGap,
+			BarWidth:   r.barWidth,
+			BarGap:     r.barGap,
+			Radius:     r.radius,
+			MinHeight:  r.minHeight,
+			Colouring:  r.colouring,
+			Palette:    r.palette.Palette(),
+			AntiAlias:  r.antialias.Kernel(),
+			Layout:     r.layout,
+			Gradient:   r.gradient.Gradient(),
+			Horizontal: r.horizontal.Horizontal(),
+		}
+	}
+
+	if r, ok := s.renderer.(*envelopeRenderer); ok {
+		return envelope.Envelope{
+			Palette:   r.palette.Palette(),
+			Mirror:    r.mirror,
+			AntiAlias: r.antialias.Kernel(),
+		}
+	}
+
+	if r, ok := s.renderer.(*spectrogramRenderer); ok {
+		return spectrogram.Spectrogram{
+			FFTSize:    r.fftSize,
+			Hop:        r.hop,
+			Window:     r.window,
+			Scale:      r.scale,
+			SampleRate: r.sampleRate,
+			MinDB:      r.minDB,
+			MaxDB:      r.maxDB,
+			Palette:    r.palette.Palette(),
+		}
+	}
+
+	if r, ok := s.renderer.(*spectralRenderer); ok {
+		return lines.Spectral{
+			SampleRate: r.sampleRate,
+			Low:        r.low,
+			High:       r.high,
+			AntiAlias:  r.antialias.Kernel(),
+			Layout:     r.layout,
+		}
+	}
+
+	if r, ok := s.renderer.(*radialRenderer); ok {
+		return radial.Radial{
+			Radius:    r.radius,
+			Mirror:    r.mirror,
 			Palette:   r.palette.Palette(),
-			AntiAlias: kernels.Vertical,
+			AntiAlias: r.antialias.Kernel(),
+		}
+	}
+
+	if r, ok := s.renderer.(*rmsRenderer); ok {
+		return rms.RMS{
+			Peak:      r.peak.Palette(),
+			RMS:       r.rms.Palette(),
+			AntiAlias: r.antialias.Kernel(),
 		}
 	}
 
@@ -154,34 +290,46 @@ func (s Style) Renderer() renderers.Renderer {
 
 func (s Style) Load(style string) (Style, error) {
 	serializable := struct {
-		Name    string           `json:"name"`
-		Width   uint             `json:"width"`
-		Height  uint             `json:"height"`
-		Padding int              `json:"padding"`
-		Scale   Scale            `json:"scale"`
-		Fill    Fill             `json:"fill"`
-		Grid    Grid             `json:"grid"`
-		Lines   *linesRenderer   `json:"lines"`
-		Columns *columnsRenderer `json:"columns"`
+		Name        string               `json:"name"`
+		Width       uint                 `json:"width"`
+		Height      uint                 `json:"height"`
+		Padding     int                  `json:"padding"`
+		Scale       Scale                `json:"scale"`
+		Supersample uint                 `json:"supersample"`
+		Fill        Fill                 `json:"fill"`
+		Grid        Grid                 `json:"grid"`
+		Lines       *linesRenderer       `json:"lines"`
+		Columns     *columnsRenderer     `json:"columns"`
+		RMS         *rmsRenderer         `json:"rms"`
+		Envelope    *envelopeRenderer    `json:"envelope"`
+		Spectrogram *spectrogramRenderer `json:"spectrogram"`
+		Spectral    *spectralRenderer    `json:"spectral"`
+		Radial      *radialRenderer      `json:"radial"`
 	}{
-		Width:   s.width,
-		Height:  s.height,
-		Padding: s.padding,
-		Scale:   s.scale,
-		Fill:    s.fill,
-		Grid:    s.grid,
+		Width:       s.width,
+		Height:      s.height,
+		Padding:     s.padding,
+		Scale:       s.scale,
+		Supersample: s.supersample,
+		Fill:        s.fill,
+		Grid:        s.grid,
 	}
 
 	if bytes, err := os.ReadFile(style); err != nil {
 		return s, err
 	} else if err := json.Unmarshal(bytes, &serializable); err != nil {
 		return s, err
+	} else if serializable.Supersample > MAX_SUPERSAMPLE {
+		return s, fmt.Errorf("invalid supersample (%v) - expected 1-%v", serializable.Supersample, MAX_SUPERSAMPLE)
+	} else if err := serializable.Fill.resolve(filepath.Dir(style)); err != nil {
+		return s, err
 	} else {
 		s.name = serializable.Name
 		s.width = serializable.Width
 		s.height = serializable.Height
 		s.padding = serializable.Padding
 		s.scale = serializable.Scale
+		s.supersample = serializable.Supersample
 		s.fill = serializable.Fill
 		s.grid = serializable.Grid
 
@@ -189,8 +337,35 @@ func (s Style) Load(style string) (Style, error) {
 			s.renderer = serializable.Lines
 		} else if serializable.Columns != nil {
 			s.renderer = serializable.Columns
+		} else if serializable.RMS != nil {
+			s.renderer = serializable.RMS
+		} else if serializable.Envelope != nil {
+			s.renderer = serializable.Envelope
+		} else if serializable.Spectrogram != nil {
+			s.renderer = serializable.Spectrogram
+		} else if serializable.Spectral != nil {
+			s.renderer = serializable.Spectral
+		} else if serializable.Radial != nil {
+			s.renderer = serializable.Radial
+		}
+
+		// ... palette files are relative to the style file
+		if r, ok := s.renderer.(resolver); ok {
+			if err := r.resolve(filepath.Dir(style)); err != nil {
+				return s, err
+			}
 		}
 
 		return s, nil
 	}
 }
+
+// join returns the path of a file referenced by a style file, resolving a relative path against the
+// style file directory.
+func join(dir, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+
+	return filepath.Join(dir, file)
+}
@@ -0,0 +1,143 @@
+package styles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const style = `{
+    "name": "test",
+    "width": 640,
+    "height": 480,
+    "padding": 0,
+    "supersample": %v,
+    "lines": {
+        "palette": "ice",
+        "antialias": "vertical"
+    }
+}`
+
+func TestLoadSupersample(t *testing.T) {
+	tests := []struct {
+		supersample uint
+		valid       bool
+	}{
+		{0, true},
+		{1, true},
+		{4, true},
+		{MAX_SUPERSAMPLE, true},
+		{MAX_SUPERSAMPLE + 1, false},
+		{64, false},
+	}
+
+	for _, test := range tests {
+		file := filepath.Join(t.TempDir(), "style.json")
+		if err := os.WriteFile(file, []byte(fmt.Sprintf(style, test.supersample)), 0666); err != nil {
+			t.Fatalf("error writing test style (%v)", err)
+		}
+
+		s, err := NewStyle().Load(file)
+		if test.valid && err != nil {
+			t.Errorf("error loading style with supersample %v (%v)", test.supersample, err)
+		} else if test.valid && s.Supersample() != test.supersample {
+			t.Errorf("incorrect supersample - expected:%v, got:%v", test.supersample, s.Supersample())
+		} else if !test.valid && err == nil {
+			t.Errorf("expected error loading style with supersample %v", test.supersample)
+		}
+	}
+}
+
+func TestLoadWithRelativePaths(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "style.json")
+	style := `{
+    "name": "test",
+    "width": 640,
+    "height": 480,
+    "fill": { "type": "image", "image": "textures/texture.png" },
+    "lines": {
+        "palette": "palette.png",
+        "antialias": "vertical",
+        "horizontal": { "played": "palette.png", "playhead": 0.5 }
+    }
+}`
+
+	os.Mkdir(filepath.Join(dir, "textures"), 0777)
+	writePNG(t, filepath.Join(dir, "textures", "texture.png"))
+	writePNG(t, filepath.Join(dir, "palette.png"))
+
+	if err := os.WriteFile(file, []byte(style), 0666); err != nil {
+		t.Fatalf("error writing test style (%v)", err)
+	}
+
+	s, err := NewStyle().Load(file)
+	if err != nil {
+		t.Fatalf("error loading style with relative paths (%v)", err)
+	}
+
+	if expected := filepath.Join(dir, "textures", "texture.png"); s.fill.Image != expected {
+		t.Errorf("incorrect image fill path - expected:%v, got:%v", expected, s.fill.Image)
+	} else if s.fill.texture == nil {
+		t.Errorf("image fill not loaded")
+	}
+
+	expected, err := load(filepath.Join(dir, "palette.png"))
+	if err != nil {
+		t.Fatalf("error loading test palette (%v)", err)
+	}
+
+	if r, ok := s.renderer.(*linesRenderer); !ok {
+		t.Errorf("incorrect renderer - expected:lines, got:%T", s.renderer)
+	} else if p := r.palette.Palette(); !reflect.DeepEqual(p, expected) {
+		t.Errorf("incorrect palette\n   expected:%+v\n   got:     %+v", expected, p)
+	} else if h := r.horizontal.Horizontal(); h == nil || h.Played == nil || !reflect.DeepEqual(*h.Played, expected) {
+		t.Errorf("incorrect played palette\n   expected:%+v\n   got:     %+v", expected, h)
+	}
+
+	// ... paths outside a style file are relative to the working directory
+	fill := Fill{}
+	if err := fill.Set("image:textures/texture.png"); err == nil {
+		t.Errorf("expected error for image fill relative to the working directory")
+	}
+}
+
+func TestLoadWithMissingFiles(t *testing.T) {
+	tests := []string{
+		`{ "fill": { "type": "image", "image": "no-such-file.png" } }`,
+		`{ "fill": "image:no-such-file.png" }`,
+		`{ "lines": { "palette": "no-such-file.png", "antialias": "vertical" } }`,
+		`{ "rms": { "peak": { "palette": "no-such-file.png" } } }`,
+	}
+
+	for _, style := range tests {
+		file := filepath.Join(t.TempDir(), "style.json")
+		if err := os.WriteFile(file, []byte(style), 0666); err != nil {
+			t.Fatalf("error writing test style (%v)", err)
+		}
+
+		if _, err := NewStyle().Load(file); err == nil {
+			t.Errorf("expected error loading style %v", style)
+		}
+	}
+}
+
+// The shipped styles predate the grid object keys and rely on the default square grid, with only
+// the colour and overlay taken from the style.
+func TestLoadShippedStyleGrids(t *testing.T) {
+	files, err := filepath.Glob("../.styles/*.json")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("error listing shipped styles (%v)", err)
+	}
+
+	for _, file := range files {
+		s, err := NewStyle().Load(file)
+		if err != nil {
+			t.Errorf("error loading style %v (%v)", file, err)
+		} else if s.grid.Grid != "square" || s.grid.Size != "~64" {
+			t.Errorf("incorrect %v grid - expected:square:~64, got:%v:%v", filepath.Base(file), s.grid.Grid, s.grid.Size)
+		}
+	}
+}